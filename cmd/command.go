@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fieldreserve/migrations"
+	"fieldreserve/storage"
 	"log"
 	"os"
 
@@ -12,6 +14,7 @@ func Command(db *gorm.DB) {
 	migrate := false
 	seed := false
 	rollback := false
+	migrateFiles := false
 
 	for _, arg := range os.Args[1:] {
 		if arg == "--migrate" {
@@ -25,6 +28,10 @@ func Command(db *gorm.DB) {
 		if arg == "--rollback" {
 			rollback = true
 		}
+
+		if arg == "--migrate-storage" {
+			migrateFiles = true
+		}
 	}
 
 	if migrate {
@@ -50,4 +57,18 @@ func Command(db *gorm.DB) {
 
 		log.Println("rollback complete successfully")
 	}
+
+	if migrateFiles {
+		store, err := storage.NewFromEnv()
+		if err != nil {
+			log.Fatalf("error storage: %v", err)
+		}
+
+		copied, err := migrateStorage(context.Background(), store)
+		if err != nil {
+			log.Fatalf("error migrating files to storage: %v", err)
+		}
+
+		log.Printf("copied %d files to storage", copied)
+	}
 }
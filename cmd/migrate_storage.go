@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fieldreserve/storage"
+	"log"
+	"mime"
+	"os"
+	"path/filepath"
+)
+
+// legacyUploadDirs are the directories uploads were written to before the storage
+// backend existed, with the folder each one maps to.
+var legacyUploadDirs = []struct {
+	dir    string
+	folder string
+}{
+	{dir: "./assets/fields", folder: storage.FolderFields},
+	{dir: "./assets/proof", folder: storage.FolderProofs},
+	{dir: "./storage/proof", folder: storage.FolderProofs},
+}
+
+// migrateStorage copies files from the legacy upload directories into the configured
+// backend. File names are kept, so database records stay valid; the sources are left in
+// place to be removed once the copy has been checked.
+func migrateStorage(ctx context.Context, store storage.Storage) (int, error) {
+	copied := 0
+	for _, legacy := range legacyUploadDirs {
+		entries, err := os.ReadDir(legacy.dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return copied, err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			key, err := storage.Key(legacy.folder, entry.Name())
+			if err != nil {
+				log.Printf("skipping %s: %v", entry.Name(), err)
+				continue
+			}
+
+			source := filepath.Join(legacy.dir, entry.Name())
+			if sameLocalFile(store, key, source) {
+				continue
+			}
+
+			if err := copyToStorage(ctx, store, key, source); err != nil {
+				return copied, err
+			}
+			copied++
+		}
+	}
+
+	return copied, nil
+}
+
+func copyToStorage(ctx context.Context, store storage.Storage, key, source string) error {
+	file, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return store.Put(ctx, key, file, mime.TypeByExtension(filepath.Ext(source)))
+}
+
+// sameLocalFile reports whether key already resolves to source, which happens when the
+// local backend's root is one of the legacy directories.
+func sameLocalFile(store storage.Storage, key, source string) bool {
+	local, ok := store.(*storage.LocalStorage)
+	if !ok {
+		return false
+	}
+
+	target, err := local.Path(key)
+	if err != nil {
+		return false
+	}
+
+	targetInfo, err := os.Stat(target)
+	if err != nil {
+		return false
+	}
+	sourceInfo, err := os.Stat(source)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(targetInfo, sourceInfo)
+}
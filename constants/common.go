@@ -3,6 +3,13 @@ package constants
 const (
 	ENUM_ROLE_ADMIN = "admin"
 	ENUM_ROLE_USER  = "user"
+	ENUM_ROLE_STAFF = "staff"
+
+	TOKEN_TYPE_ACCESS  = "access"
+	TOKEN_TYPE_REFRESH = "refresh"
+
+	ENUM_USER_TOKEN_PASSWORD_RESET     = "password_reset"
+	ENUM_USER_TOKEN_EMAIL_VERIFICATION = "email_verification"
 
 	ENUM_RUN_PRODUCTION = "production"
 	ENUM_RUN_TESTING    = "testing"
@@ -10,10 +17,39 @@ const (
 	ENUM_PAGINATION_LIMIT = 10
 	ENUM_PAGINATION_PAGE  = 1
 
-	ENUM_STATUS_BOOKING_PENDING = "pending"
-	ENUM_STATUS_BOOKING_WAITING = "waiting_verification"
-	ENUM_STATUS_BOOKING_CALCEL  = "cancelled"
-	ENUM_STATUS_BOOKING_BOOKED  = "booked"
+	ENUM_STATUS_BOOKING_PENDING   = "pending"
+	ENUM_STATUS_BOOKING_WAITING   = "waiting_verification"
+	ENUM_STATUS_BOOKING_CALCEL    = "cancelled"
+	ENUM_STATUS_BOOKING_BOOKED    = "booked"
+	ENUM_STATUS_BOOKING_REJECTED  = "rejected"
+	ENUM_STATUS_BOOKING_COMPLETED = "completed"
+	ENUM_STATUS_BOOKING_NO_SHOW   = "no_show"
+	ENUM_STATUS_BOOKING_REFUNDED  = "refunded"
+
+	ENUM_ACTOR_SYSTEM = "system"
+
+	ENUM_CANCEL_REASON_PAYMENT_EXPIRED  = "payment_expired"
+	ENUM_CANCEL_REASON_USER_REQUEST     = "user_request"
+	ENUM_CANCEL_REASON_SERIES_CANCELLED = "series_cancelled"
+	ENUM_CANCEL_REASON_ADMIN_CANCELLED  = "admin_cancelled"
+	ENUM_NO_SHOW_REASON_MISSED_CHECK_IN = "missed_check_in"
+
+	ENUM_BOOKING_SERIES_ACTIVE    = "active"
+	ENUM_BOOKING_SERIES_CANCELLED = "cancelled"
+
+	ENUM_WAITLIST_WAITING   = "waiting"
+	ENUM_WAITLIST_OFFERED   = "offered"
+	ENUM_WAITLIST_FULFILLED = "fulfilled"
+	ENUM_WAITLIST_EXPIRED   = "expired"
+	ENUM_WAITLIST_CANCELLED = "cancelled"
+
+	ENUM_PRICE_ITEM_BASE_RATE = "base_rate"
+	ENUM_PRICE_ITEM_SURCHARGE = "surcharge"
+	ENUM_PRICE_ITEM_DISCOUNT  = "discount"
+	ENUM_PRICE_ITEM_FEE       = "fee"
+
+	ENUM_DISCOUNT_PERCENT = "percent"
+	ENUM_DISCOUNT_FIXED   = "fixed"
 
 	Sunday    = 0
 	Monday    = 1
@@ -23,3 +59,16 @@ const (
 	Friday    = 5
 	Saturday  = 6
 )
+
+// InactiveBookingStatuses are the statuses whose bookings no longer hold their slot.
+var InactiveBookingStatuses = []string{
+	ENUM_STATUS_BOOKING_CALCEL,
+	ENUM_STATUS_BOOKING_REJECTED,
+	ENUM_STATUS_BOOKING_REFUNDED,
+}
+
+// OpenWaitlistStatuses are the statuses of waitlist entries still competing for a slot.
+var OpenWaitlistStatuses = []string{
+	ENUM_WAITLIST_WAITING,
+	ENUM_WAITLIST_OFFERED,
+}
@@ -4,67 +4,156 @@ import "errors"
 
 const (
 	// failed
-	MESSAGE_FAILED_PROSES_REQUEST      = "failed proses request"
-	MESSAGE_FAILED_ACCESS_DENIED       = "failed access denied"
-	MESSAGE_FAILED_TOKEN_NOT_FOUND     = "failed token not found"
-	MESSAGE_FAILED_TOKEN_NOT_VALID     = "failed token not valid"
-	MESSAGE_FAILED_TOKEN_DENIED_ACCESS = "failed token denied access"
-	MESSAGE_FAILED_GET_DATA_FROM_BODY  = "failed get data from body"
-	MESSAGE_FAILED_CREATE_USER         = "failed create user"
-	MESSAGE_FAILED_GET_DETAIL_USER     = "failed get detail user"
-	MESSAGE_FAILED_GET_LIST_USER       = "failed get list user"
-	MESSAGE_FAILED_UPDATE_USER         = "failed update user"
-	MESSAGE_FAILED_DELETE_USER         = "failed delete user"
-	MESSAGE_FAILED_LOGIN_USER          = "failed login user"
-	MESSAGE_FAILED_CREATE_CATEGORY     = "failed create category"
-	MESSAGE_FAILED_GET_ALL_CATEGORY    = "failed get all category"
-	MESSAGE_FAILED_UUID_FORMAT         = "failed uuid format"
-	MESSAGE_FAILED_GET_DETAIL_CATEGORY = "failed get detail category"
-	MESSAGE_FAILED_UPDATE_CATEGORY     = "failed update category"
-	MESSAGE_FAILED_DELETE_CATEGORY     = "failed delete category"
-	MESSAGE_FAILED_CREATE_FIELD        = "failed create field"
-	MESSAGE_FAILED_GET_ALL_FIELD       = "failed get all field"
-	MESSAGE_FAILED_GET_DETAIL_FIELD    = "failed get detail field"
-	MESSAGE_FAILED_UPDATE_FIELD        = "failed update field"
-	MESSAGE_FAILED_DELETE_FIELD        = "failed delete field"
-	MESSAGE_FAILED_CREATE_SCHEDULE     = "failed create schedule"
-	MESSAGE_FAILED_GET_ALL_SCHEDULE    = "failed get all schedule"
-	MESSAGE_FAILED_UPDATE_SCHEDULE     = "failed update schedule"
-	MESSAGE_FAILED_DELETE_SCHEDULE     = "failed delete schedule"
-	MESSAGE_FAILED_GET_DETAIL_SCHEDULE = "failed get detail schedule"
-	MESSAGE_FAILED_CREATE_BOOKING      = "failed create booking"
-	MESSAGE_FAILED_GET_ALL_BOOKING     = "failed get all bookings"
-	MESSAGE_FAILED_GET_DETAIL_BOOKING  = "failed get detail booking"
-	MESSAGE_FAILED_UPDATE_BOOKING      = "failed update booking"
-	MESSAGE_FAILED_DELETE_BOOKING      = "failed delete booking"
-	MESSAGE_FAILED_GET_BOOKING         = "failed get data booking"
+	MESSAGE_FAILED_PROSES_REQUEST         = "failed proses request"
+	MESSAGE_FAILED_ACCESS_DENIED          = "failed access denied"
+	MESSAGE_FAILED_TOKEN_NOT_FOUND        = "failed token not found"
+	MESSAGE_FAILED_TOKEN_NOT_VALID        = "failed token not valid"
+	MESSAGE_FAILED_TOKEN_DENIED_ACCESS    = "failed token denied access"
+	MESSAGE_FAILED_GET_DATA_FROM_BODY     = "failed get data from body"
+	MESSAGE_FAILED_CREATE_USER            = "failed create user"
+	MESSAGE_FAILED_GET_DETAIL_USER        = "failed get detail user"
+	MESSAGE_FAILED_GET_LIST_USER          = "failed get list user"
+	MESSAGE_FAILED_UPDATE_USER            = "failed update user"
+	MESSAGE_FAILED_DELETE_USER            = "failed delete user"
+	MESSAGE_FAILED_LOGIN_USER             = "failed login user"
+	MESSAGE_FAILED_CREATE_CATEGORY        = "failed create category"
+	MESSAGE_FAILED_GET_ALL_CATEGORY       = "failed get all category"
+	MESSAGE_FAILED_UUID_FORMAT            = "failed uuid format"
+	MESSAGE_FAILED_GET_DETAIL_CATEGORY    = "failed get detail category"
+	MESSAGE_FAILED_UPDATE_CATEGORY        = "failed update category"
+	MESSAGE_FAILED_DELETE_CATEGORY        = "failed delete category"
+	MESSAGE_FAILED_CREATE_FIELD           = "failed create field"
+	MESSAGE_FAILED_GET_ALL_FIELD          = "failed get all field"
+	MESSAGE_FAILED_GET_DETAIL_FIELD       = "failed get detail field"
+	MESSAGE_FAILED_UPDATE_FIELD           = "failed update field"
+	MESSAGE_FAILED_DELETE_FIELD           = "failed delete field"
+	MESSAGE_FAILED_ADD_FIELD_IMAGE        = "failed add field image"
+	MESSAGE_FAILED_REORDER_FIELD_IMAGES   = "failed reorder field images"
+	MESSAGE_FAILED_DELETE_FIELD_IMAGE     = "failed delete field image"
+	MESSAGE_FAILED_CREATE_SCHEDULE        = "failed create schedule"
+	MESSAGE_FAILED_GET_ALL_SCHEDULE       = "failed get all schedule"
+	MESSAGE_FAILED_UPDATE_SCHEDULE        = "failed update schedule"
+	MESSAGE_FAILED_DELETE_SCHEDULE        = "failed delete schedule"
+	MESSAGE_FAILED_GET_DETAIL_SCHEDULE    = "failed get detail schedule"
+	MESSAGE_FAILED_CREATE_BOOKING         = "failed create booking"
+	MESSAGE_FAILED_GET_ALL_BOOKING        = "failed get all bookings"
+	MESSAGE_FAILED_GET_DETAIL_BOOKING     = "failed get detail booking"
+	MESSAGE_FAILED_UPDATE_BOOKING         = "failed update booking"
+	MESSAGE_FAILED_DELETE_BOOKING         = "failed delete booking"
+	MESSAGE_FAILED_CANCEL_BOOKING         = "failed cancel booking"
+	MESSAGE_FAILED_RESCHEDULE_BOOKING     = "failed reschedule booking"
+	MESSAGE_FAILED_GET_BOOKING            = "failed get data booking"
+	MESSAGE_FAILED_GET_AVAILABILITY       = "failed get field availability"
+	MESSAGE_FAILED_QUOTE_BOOKING          = "failed quote booking"
+	MESSAGE_FAILED_CREATE_PRICING_RULE    = "failed create pricing rule"
+	MESSAGE_FAILED_GET_PRICING_RULE       = "failed get pricing rule"
+	MESSAGE_FAILED_UPDATE_PRICING_RULE    = "failed update pricing rule"
+	MESSAGE_FAILED_DELETE_PRICING_RULE    = "failed delete pricing rule"
+	MESSAGE_FAILED_CREATE_BOOKING_SERIES  = "failed create booking series"
+	MESSAGE_FAILED_GET_BOOKING_SERIES     = "failed get booking series"
+	MESSAGE_FAILED_UPDATE_BOOKING_SERIES  = "failed update booking series"
+	MESSAGE_FAILED_CANCEL_BOOKING_SERIES  = "failed cancel booking series"
+	MESSAGE_FAILED_GET_BOOKING_TIMELINE   = "failed get booking timeline"
+	MESSAGE_FAILED_JOIN_WAITLIST          = "failed join waitlist"
+	MESSAGE_FAILED_GET_WAITLIST           = "failed get waitlist"
+	MESSAGE_FAILED_LEAVE_WAITLIST         = "failed leave waitlist"
+	MESSAGE_FAILED_GET_WAITLIST_DEMAND    = "failed get waitlist demand"
+	MESSAGE_FAILED_CREATE_VOUCHER         = "failed create voucher"
+	MESSAGE_FAILED_GET_VOUCHER            = "failed get voucher"
+	MESSAGE_FAILED_UPDATE_VOUCHER         = "failed update voucher"
+	MESSAGE_FAILED_DELETE_VOUCHER         = "failed delete voucher"
+	MESSAGE_FAILED_CREATE_PAYMENT         = "failed create payment"
+	MESSAGE_FAILED_GET_PAYMENT            = "failed get payment"
+	MESSAGE_FAILED_SIMULATE_PAYMENT       = "failed simulate payment"
+	MESSAGE_FAILED_REFUND_PAYMENT         = "failed refund payment"
+	MESSAGE_FAILED_PAYMENT_WEBHOOK        = "failed payment webhook"
+	MESSAGE_FAILED_GET_PAYMENT_QR         = "failed get payment qr"
+	MESSAGE_FAILED_GENERATE_INVOICE       = "failed generate invoice"
+	MESSAGE_FAILED_UPLOAD_PAYMENT_PROOF   = "failed upload payment proof"
+	MESSAGE_FAILED_REJECT_PAYMENT_PROOF   = "failed reject payment proof"
+	MESSAGE_FAILED_GET_VERIFICATION_QUEUE = "failed get verification queue"
+	MESSAGE_FAILED_GET_PAYMENT_PROOF      = "failed get payment proof"
+	MESSAGE_FAILED_GET_FILE               = "failed get file"
+	MESSAGE_FAILED_CHECK_IN               = "failed check in booking"
+	MESSAGE_FAILED_UPDATE_USER_ROLE       = "failed update user role"
+	MESSAGE_FAILED_REFRESH_TOKEN          = "failed refresh token"
+	MESSAGE_FAILED_LOGOUT                 = "failed logout"
+	MESSAGE_FAILED_GET_SESSIONS           = "failed get sessions"
+	MESSAGE_FAILED_REVOKE_SESSIONS        = "failed revoke sessions"
+	MESSAGE_FAILED_FORGOT_PASSWORD        = "failed request password reset"
+	MESSAGE_FAILED_RESET_PASSWORD         = "failed reset password"
+	MESSAGE_FAILED_VERIFY_EMAIL           = "failed verify email"
+	MESSAGE_FAILED_RESEND_VERIFICATION    = "failed resend verification email"
 
 	// success
-	MESSAGE_SUCCESS_CREATE_USER         = "success create user"
-	MESSAGE_SUCCESS_GET_DETAIL_USER     = "success get detail user"
-	MESSAGE_SUCCESS_GET_LIST_USER       = "success get list user"
-	MESSAGE_SUCCESS_UPDATE_USER         = "success update user"
-	MESSAGE_SUCCESS_DELETE_USER         = "success delete user"
-	MESSAGE_SUCCESS_CREATE_CATEGORY     = "success create category"
-	MESSAGE_SUCCESS_GET_ALL_CATEGORY    = "success get all category"
-	MESSAGE_SUCCESS_GET_DETAIL_CATEGORY = "success get detail category"
-	MESSAGE_SUCCESS_UPDATE_CATEGORY     = "success update category"
-	MESSAGE_SUCCESS_DELETE_CATEGORY     = "success delete category"
-	MESSAGE_SUCCESS_CREATE_FIELD        = "success create field"
-	MESSAGE_SUCCESS_GET_ALL_FIELD       = "success get all field"
-	MESSAGE_SUCCESS_GET_DETAIL_FIELD    = "success get detail field"
-	MESSAGE_SUCCESS_UPDATE_FIELD        = "success update field"
-	MESSAGE_SUCCESS_DELETE_FIELD        = "success delete field"
-	MESSAGE_SUCCESS_CREATE_SCHEDULE     = "success create schedule"
-	MESSAGE_SUCCESS_GET_ALL_SCHEDULE    = "success get all schedule"
-	MESSAGE_SUCCESS_UPDATE_SCHEDULE     = "success update schedule"
-	MESSAGE_SUCCESS_DELETE_SCHEDULE     = "success delete schedule"
-	MESSAGE_SUCCESS_GET_DETAIL_SCHEDULE = "success get detail schedule"
-	MESSAGE_SUCCESS_CREATE_BOOKING      = "success create booking"
-	MESSAGE_SUCCESS_GET_ALL_BOOKING     = "success get all bookings"
-	MESSAGE_SUCCESS_GET_DETAIL_BOOKING  = "success get detail booking"
-	MESSAGE_SUCCESS_UPDATE_BOOKING      = "success update booking"
-	MESSAGE_SUCCESS_DELETE_BOOKING      = "success delete booking"
+	MESSAGE_SUCCESS_CREATE_USER            = "success create user"
+	MESSAGE_SUCCESS_GET_DETAIL_USER        = "success get detail user"
+	MESSAGE_SUCCESS_GET_LIST_USER          = "success get list user"
+	MESSAGE_SUCCESS_UPDATE_USER            = "success update user"
+	MESSAGE_SUCCESS_DELETE_USER            = "success delete user"
+	MESSAGE_SUCCESS_CREATE_CATEGORY        = "success create category"
+	MESSAGE_SUCCESS_GET_ALL_CATEGORY       = "success get all category"
+	MESSAGE_SUCCESS_GET_DETAIL_CATEGORY    = "success get detail category"
+	MESSAGE_SUCCESS_UPDATE_CATEGORY        = "success update category"
+	MESSAGE_SUCCESS_DELETE_CATEGORY        = "success delete category"
+	MESSAGE_SUCCESS_CREATE_FIELD           = "success create field"
+	MESSAGE_SUCCESS_GET_ALL_FIELD          = "success get all field"
+	MESSAGE_SUCCESS_GET_DETAIL_FIELD       = "success get detail field"
+	MESSAGE_SUCCESS_UPDATE_FIELD           = "success update field"
+	MESSAGE_SUCCESS_DELETE_FIELD           = "success delete field"
+	MESSAGE_SUCCESS_ADD_FIELD_IMAGE        = "success add field image"
+	MESSAGE_SUCCESS_REORDER_FIELD_IMAGES   = "success reorder field images"
+	MESSAGE_SUCCESS_DELETE_FIELD_IMAGE     = "success delete field image"
+	MESSAGE_SUCCESS_CREATE_SCHEDULE        = "success create schedule"
+	MESSAGE_SUCCESS_GET_ALL_SCHEDULE       = "success get all schedule"
+	MESSAGE_SUCCESS_UPDATE_SCHEDULE        = "success update schedule"
+	MESSAGE_SUCCESS_DELETE_SCHEDULE        = "success delete schedule"
+	MESSAGE_SUCCESS_GET_DETAIL_SCHEDULE    = "success get detail schedule"
+	MESSAGE_SUCCESS_CREATE_BOOKING         = "success create booking"
+	MESSAGE_SUCCESS_GET_ALL_BOOKING        = "success get all bookings"
+	MESSAGE_SUCCESS_GET_DETAIL_BOOKING     = "success get detail booking"
+	MESSAGE_SUCCESS_UPDATE_BOOKING         = "success update booking"
+	MESSAGE_SUCCESS_DELETE_BOOKING         = "success delete booking"
+	MESSAGE_SUCCESS_CANCEL_BOOKING         = "success cancel booking"
+	MESSAGE_SUCCESS_RESCHEDULE_BOOKING     = "success reschedule booking"
+	MESSAGE_SUCCESS_GET_AVAILABILITY       = "success get field availability"
+	MESSAGE_SUCCESS_QUOTE_BOOKING          = "success quote booking"
+	MESSAGE_SUCCESS_CREATE_PRICING_RULE    = "success create pricing rule"
+	MESSAGE_SUCCESS_GET_PRICING_RULE       = "success get pricing rule"
+	MESSAGE_SUCCESS_UPDATE_PRICING_RULE    = "success update pricing rule"
+	MESSAGE_SUCCESS_DELETE_PRICING_RULE    = "success delete pricing rule"
+	MESSAGE_SUCCESS_CREATE_BOOKING_SERIES  = "success create booking series"
+	MESSAGE_SUCCESS_GET_BOOKING_SERIES     = "success get booking series"
+	MESSAGE_SUCCESS_UPDATE_BOOKING_SERIES  = "success update booking series"
+	MESSAGE_SUCCESS_CANCEL_BOOKING_SERIES  = "success cancel booking series"
+	MESSAGE_SUCCESS_GET_BOOKING_TIMELINE   = "success get booking timeline"
+	MESSAGE_SUCCESS_JOIN_WAITLIST          = "success join waitlist"
+	MESSAGE_SUCCESS_GET_WAITLIST           = "success get waitlist"
+	MESSAGE_SUCCESS_LEAVE_WAITLIST         = "success leave waitlist"
+	MESSAGE_SUCCESS_GET_WAITLIST_DEMAND    = "success get waitlist demand"
+	MESSAGE_SUCCESS_CREATE_VOUCHER         = "success create voucher"
+	MESSAGE_SUCCESS_GET_VOUCHER            = "success get voucher"
+	MESSAGE_SUCCESS_UPDATE_VOUCHER         = "success update voucher"
+	MESSAGE_SUCCESS_DELETE_VOUCHER         = "success delete voucher"
+	MESSAGE_SUCCESS_CREATE_PAYMENT         = "success create payment"
+	MESSAGE_SUCCESS_GET_PAYMENT            = "success get payment"
+	MESSAGE_SUCCESS_SIMULATE_PAYMENT       = "success simulate payment"
+	MESSAGE_SUCCESS_REFUND_PAYMENT         = "success refund payment"
+	MESSAGE_SUCCESS_PAYMENT_WEBHOOK        = "success payment webhook"
+	MESSAGE_SUCCESS_UPLOAD_PAYMENT_PROOF   = "success upload payment proof"
+	MESSAGE_SUCCESS_REJECT_PAYMENT_PROOF   = "success reject payment proof"
+	MESSAGE_SUCCESS_GET_VERIFICATION_QUEUE = "success get verification queue"
+	MESSAGE_SUCCESS_GET_PAYMENT_PROOF      = "success get payment proof"
+	MESSAGE_SUCCESS_CHECK_IN               = "success check in booking"
+	MESSAGE_SUCCESS_UPDATE_USER_ROLE       = "success update user role"
+	MESSAGE_SUCCESS_REFRESH_TOKEN          = "success refresh token"
+	MESSAGE_SUCCESS_LOGOUT                 = "success logout"
+	MESSAGE_SUCCESS_GET_SESSIONS           = "success get sessions"
+	MESSAGE_SUCCESS_REVOKE_SESSIONS        = "success revoke sessions"
+	MESSAGE_SUCCESS_FORGOT_PASSWORD        = "success request password reset"
+	MESSAGE_SUCCESS_RESET_PASSWORD         = "success reset password"
+	MESSAGE_SUCCESS_VERIFY_EMAIL           = "success verify email"
+	MESSAGE_SUCCESS_RESEND_VERIFICATION    = "success resend verification email"
 )
 
 var (
@@ -75,6 +164,14 @@ var (
 	ErrDecryptToken            = errors.New("unable to decrypt token")
 	ErrTokenInvalid            = errors.New("token is invalid")
 	ErrValidateToken           = errors.New("unable to validate token")
+	ErrInvalidRefreshToken     = errors.New("refresh token is invalid or expired")
+	ErrRefreshTokenReused      = errors.New("refresh token has already been used")
+	ErrRefreshToken            = errors.New("unable to refresh token")
+	ErrTokenRevoked            = errors.New("token has been revoked")
+	ErrCheckTokenRevocation    = errors.New("unable to check token revocation")
+	ErrLogout                  = errors.New("unable to log out")
+	ErrGetSessions             = errors.New("unable to retrieve sessions")
+	ErrRevokeSessions          = errors.New("unable to revoke sessions")
 
 	// User-related errors
 	ErrInvalidName              = errors.New("invalid name provided")
@@ -93,6 +190,16 @@ var (
 	ErrDeniedAccess             = errors.New("access denied")
 	ErrGetPermissionsByRoleID   = errors.New("unable to retrieve permissions for role ID")
 	ErrInvalidPhoneNumber       = errors.New("invalid phone number provided")
+	ErrInvalidRole              = errors.New("invalid role provided")
+	ErrInvalidResetToken        = errors.New("password reset token is invalid or expired")
+	ErrForgotPassword           = errors.New("unable to send password reset email")
+	ErrResetPassword            = errors.New("unable to reset password")
+	ErrInvalidVerificationToken = errors.New("email verification token is invalid or expired")
+	ErrEmailAlreadyVerified     = errors.New("email address already verified")
+	ErrEmailNotVerified         = errors.New("email address not verified")
+	ErrVerificationRateLimited  = errors.New("too many verification emails, please try again later")
+	ErrSendVerification         = errors.New("unable to send verification email")
+	ErrVerifyEmail              = errors.New("unable to verify email")
 
 	// Category-related errors
 	ErrCreateCategory     = errors.New("unable to create category")
@@ -103,14 +210,18 @@ var (
 	ErrDeleteCategoryByID = errors.New("unable to delete category by ID")
 
 	// Field-related errors
-	ErrCreateField       = errors.New("unable to create field")
-	ErrGetFieldByID      = errors.New("unable to retrieve field by ID")
-	ErrGetAllField       = errors.New("unable to retrieve all fields")
-	ErrUpdateField       = errors.New("unable to update field")
-	ErrDeleteFieldByID   = errors.New("unable to delete field by ID")
-	ErrInvalidFieldPrice = errors.New("field price cannot be negative")
-	ErrSaveImages        = errors.New("unable to save image")
-	ErrFieldNotFound     = errors.New("field not found")
+	ErrCreateField        = errors.New("unable to create field")
+	ErrGetFieldByID       = errors.New("unable to retrieve field by ID")
+	ErrGetAllField        = errors.New("unable to retrieve all fields")
+	ErrUpdateField        = errors.New("unable to update field")
+	ErrDeleteFieldByID    = errors.New("unable to delete field by ID")
+	ErrInvalidFieldPrice  = errors.New("field price cannot be negative")
+	ErrSaveImages         = errors.New("unable to save image")
+	ErrInvalidImage       = errors.New("image must be a JPEG or PNG file")
+	ErrImageTooLarge      = errors.New("image exceeds the maximum upload size or dimensions")
+	ErrFieldNotFound      = errors.New("field not found")
+	ErrFieldImageNotFound = errors.New("field image not found")
+	ErrInvalidImageOrder  = errors.New("image order must list every image of the field exactly once")
 
 	// Schedule-related errors
 	ErrCreateSchedule         = errors.New("unable to create schedule")
@@ -122,6 +233,10 @@ var (
 	ErrInvalidDayOfWeek       = errors.New("invalid day of the week")
 	ErrCloseTimeMustAfterOpen = errors.New("closing time must be after opening time")
 	ErrInvalidTimeFormat      = errors.New("invalid time format, expected HH:MM")
+	ErrInvalidDateRange       = errors.New("invalid date range, end date must not be before start date")
+	ErrDateRangeTooLong       = errors.New("date range exceeds the maximum allowed days")
+	ErrInvalidSlotDuration    = errors.New("invalid slot duration, expected between 15 and 240 minutes")
+	ErrGetAvailability        = errors.New("unable to retrieve field availability")
 
 	// Booking-related errors
 	ErrBookingTooSoon          = errors.New("booking must be at least 2 hours in advance")
@@ -141,8 +256,93 @@ var (
 	ErrInvalidTimeRange        = errors.New("invalid time range provided")
 	ErrInvalidStatusTransition = errors.New("invalid status transition for booking")
 	ErrBookingAlreadyFinal     = errors.New("booking has already been finalized and cannot be updated")
-	ErrInvalidStatusUpdate     = errors.New("invalid status update, only 'booked' or 'cancelled' allowed")
+	ErrInvalidStatusUpdate     = errors.New("invalid booking status")
 	ErrBookingNotFound         = errors.New("")
+	ErrCalculatePrice          = errors.New("unable to calculate booking price")
+	ErrGetBookingTimeline      = errors.New("unable to retrieve booking status history")
+	ErrRescheduleTooLate       = errors.New("booking can no longer be rescheduled; too close to booking time")
+	ErrRescheduleLimitReached  = errors.New("booking has reached the maximum number of reschedules")
+	ErrRescheduleNotAllowed    = errors.New("booking cannot be rescheduled in its current status")
+	ErrPaymentProofNotAllowed  = errors.New("payment proof can only be uploaded for unpaid bookings")
+	ErrPaymentDeadlinePassed   = errors.New("payment deadline has passed")
+	ErrSavePaymentProof        = errors.New("unable to save payment proof")
+	ErrProofNotAwaitingReview  = errors.New("booking has no payment proof awaiting review")
+	ErrRejectProofTooLate      = errors.New("booking is too close to its start time for a new payment window; cancel it instead")
+	ErrGetVerificationQueue    = errors.New("unable to retrieve verification queue")
+	ErrPaymentProofNotFound    = errors.New("payment proof not found")
+	ErrInvalidSignedURL        = errors.New("link is invalid or has expired")
+	ErrInvalidCheckInToken     = errors.New("check-in code is invalid")
+	ErrCheckInNotAllowed       = errors.New("only confirmed bookings can be checked in")
+	ErrAlreadyCheckedIn        = errors.New("booking has already been checked in")
+	ErrCheckInNotOpen          = errors.New("check-in has not opened for this booking yet")
+	ErrCheckInClosed           = errors.New("check-in window for this booking has closed")
+
+	// File-related errors
+	ErrFileNotFound = errors.New("file not found")
+	ErrReadFile     = errors.New("unable to read file")
+
+	// Pricing rule-related errors
+	ErrCreatePricingRule   = errors.New("unable to create pricing rule")
+	ErrGetPricingRule      = errors.New("unable to retrieve pricing rule")
+	ErrPricingRuleNotFound = errors.New("pricing rule not found")
+	ErrUpdatePricingRule   = errors.New("unable to update pricing rule")
+	ErrDeletePricingRule   = errors.New("unable to delete pricing rule")
+	ErrInvalidRulePrice    = errors.New("price per hour cannot be negative")
+	ErrInvalidDateFormat   = errors.New("invalid date format, expected YYYY-MM-DD")
+
+	// Booking series-related errors
+	ErrCreateBookingSeries      = errors.New("unable to create booking series")
+	ErrGetBookingSeries         = errors.New("unable to retrieve booking series")
+	ErrBookingSeriesNotFound    = errors.New("booking series not found")
+	ErrUpdateBookingSeries      = errors.New("unable to update booking series")
+	ErrCancelBookingSeries      = errors.New("unable to cancel booking series")
+	ErrSeriesEndRequired        = errors.New("either until_date or count is required for a booking series")
+	ErrSeriesTooManyOccurrences = errors.New("booking series exceeds the maximum number of occurrences")
+	ErrBookingSeriesCancelled   = errors.New("booking series has been cancelled")
+	ErrOccurrenceNotInSeries    = errors.New("booking does not belong to this series")
+
+	// Waitlist-related errors
+	ErrJoinWaitlist          = errors.New("unable to join waitlist")
+	ErrGetWaitlist           = errors.New("unable to retrieve waitlist")
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+	ErrLeaveWaitlist         = errors.New("unable to leave waitlist")
+	ErrAlreadyOnWaitlist     = errors.New("already on the waitlist for this slot")
+	ErrSlotAvailable         = errors.New("slot is available, book it directly instead")
+	ErrSlotOnHold            = errors.New("slot is being held for a waitlisted customer")
+	ErrWaitlistEntryClosed   = errors.New("waitlist entry is no longer active")
+
+	// Voucher-related errors
+	ErrCreateVoucher          = errors.New("unable to create voucher")
+	ErrGetVoucher             = errors.New("unable to retrieve voucher")
+	ErrVoucherNotFound        = errors.New("voucher not found")
+	ErrUpdateVoucher          = errors.New("unable to update voucher")
+	ErrDeleteVoucher          = errors.New("unable to delete voucher")
+	ErrVoucherCodeExists      = errors.New("voucher code already exists")
+	ErrInvalidDiscount        = errors.New("invalid discount value")
+	ErrPromoCodeInvalid       = errors.New("promo code is invalid")
+	ErrPromoCodeExpired       = errors.New("promo code is not valid at this time")
+	ErrPromoCodeNotApplicable = errors.New("promo code does not apply to this field")
+	ErrPromoCodeUsedUp        = errors.New("promo code has reached its usage limit")
+	ErrPromoCodeUserLimit     = errors.New("you have already used this promo code the maximum number of times")
+	ErrVoucherMinSpend        = errors.New("booking total does not meet the minimum spend for this promo code")
+	ErrRedeemVoucher          = errors.New("unable to redeem promo code")
+
+	// Payment-related errors
+	ErrCreatePayment                = errors.New("unable to create payment")
+	ErrGetPayment                   = errors.New("unable to get payment")
+	ErrUpdatePayment                = errors.New("unable to update payment")
+	ErrPaymentNotFound              = errors.New("payment not found")
+	ErrPaymentNotAllowed            = errors.New("booking is not awaiting payment")
+	ErrPaymentNotPaid               = errors.New("only paid payments can be refunded")
+	ErrPaymentAmountMismatch        = errors.New("paid amount does not match the charge")
+	ErrUnknownPaymentProvider       = errors.New("unknown payment provider")
+	ErrInvalidWebhookSignature      = errors.New("invalid webhook signature")
+	ErrInvalidWebhookPayload        = errors.New("invalid webhook payload")
+	ErrPaymentSimulationUnavailable = errors.New("payment simulation is only available with the mock provider")
+	ErrInvalidRefundAmount          = errors.New("refund amount exceeds the refundable balance")
+	ErrRefundPayment                = errors.New("unable to refund payment")
+	ErrQRISNotConfigured            = errors.New("QRIS payment is not configured")
+	ErrGeneratePaymentQR            = errors.New("unable to generate payment QR")
 
 	// General errors
 	ErrInternalServer = errors.New("internal server error")
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"fieldreserve/constants"
 	"fieldreserve/dto"
 	"fieldreserve/service"
@@ -17,10 +18,21 @@ type (
 		CreateBooking(ctx *gin.Context)
 		GetAllBooking(ctx *gin.Context)
 		GetBookingByID(ctx *gin.Context)
+		GetBookingTimeline(ctx *gin.Context)
 		GetUserBookingHistory(ctx *gin.Context)
+		QuoteBooking(ctx *gin.Context)
 		UpdateStatusBooking(ctx *gin.Context)
+		CancelBooking(ctx *gin.Context)
+		RescheduleBooking(ctx *gin.Context)
 		DeleteBooking(ctx *gin.Context)
 		DownloadInvoice(ctx *gin.Context)
+		GetPaymentQR(ctx *gin.Context)
+		UploadPaymentProof(ctx *gin.Context)
+		RejectPaymentProof(ctx *gin.Context)
+		GetVerificationQueue(ctx *gin.Context)
+		GetPaymentProof(ctx *gin.Context)
+		GetPaymentProofURL(ctx *gin.Context)
+		CheckInBooking(ctx *gin.Context)
 	}
 
 	BookingController struct {
@@ -44,8 +56,12 @@ func (bc *BookingController) CreateBooking(ctx *gin.Context) {
 
 	result, err := bc.bookingService.CreateBooking(ctx.Request.Context(), payload)
 	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrEmailNotVerified) {
+			status = http.StatusForbidden
+		}
 		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_CREATE_BOOKING, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		ctx.AbortWithStatusJSON(status, res)
 		return
 	}
 
@@ -99,6 +115,24 @@ func (bc *BookingController) GetUserBookingHistory(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (bc *BookingController) QuoteBooking(ctx *gin.Context) {
+	var payload dto.QuoteBookingRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := bc.bookingService.QuoteBooking(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_QUOTE_BOOKING, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_QUOTE_BOOKING, result)
+	ctx.JSON(http.StatusOK, res)
+}
 
 func (bc *BookingController) GetBookingByID(ctx *gin.Context) {
 	bookingID := ctx.Param("id")
@@ -111,8 +145,13 @@ func (bc *BookingController) GetBookingByID(ctx *gin.Context) {
 
 	result, err := bc.bookingService.GetBookingByID(ctx.Request.Context(), bookingID)
 	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, constants.ErrDeniedAccess) {
+			status = http.StatusForbidden
+		}
+
 		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DETAIL_BOOKING, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+		ctx.AbortWithStatusJSON(status, res)
 		return
 	}
 
@@ -121,6 +160,26 @@ func (bc *BookingController) GetBookingByID(ctx *gin.Context) {
 }
 
 
+func (bc *BookingController) GetBookingTimeline(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := bc.bookingService.GetBookingTimeline(ctx.Request.Context(), bookingID)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_BOOKING_TIMELINE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_BOOKING_TIMELINE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (bc *BookingController) UpdateStatusBooking(ctx *gin.Context) {
 	bookingID := ctx.Param("id")
 
@@ -150,6 +209,57 @@ func (bc *BookingController) UpdateStatusBooking(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (bc *BookingController) CancelBooking(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.CancelBookingRequest
+	payload.BookingID = bookingID
+
+	result, err := bc.bookingService.CancelBooking(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_CANCEL_BOOKING, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_CANCEL_BOOKING, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bc *BookingController) RescheduleBooking(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.RescheduleBookingRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.BookingID = bookingID
+
+	result, err := bc.bookingService.RescheduleBooking(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_RESCHEDULE_BOOKING, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_RESCHEDULE_BOOKING, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (bc *BookingController) DeleteBooking(ctx *gin.Context) {
 	bookingID := ctx.Param("id")
 
@@ -183,21 +293,176 @@ func (bc *BookingController) DownloadInvoice(ctx *gin.Context) {
 		return
 	}
 
-	booking, err := bc.bookingService.GetBookingByID(ctx.Request.Context(), bookingID)
+	pdfBytes, err := bc.bookingService.GenerateInvoice(ctx.Request.Context(), bookingID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrDeniedAccess) {
+			status = http.StatusForbidden
+		}
+
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GENERATE_INVOICE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	ctx.Header("Content-Type", "application/pdf")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=invoice-%s.pdf", bookingID))
+	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+func (bc *BookingController) GetPaymentQR(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	png, err := bc.bookingService.GetPaymentQR(ctx.Request.Context(), bookingID)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_PAYMENT_QR, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+func (bc *BookingController) UploadPaymentProof(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.UploadPaymentProofRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.BookingID = bookingID
+
+	result, err := bc.bookingService.UploadPaymentProof(ctx.Request.Context(), payload)
 	if err != nil {
-		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_BOOKING, err.Error(), nil)
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UPLOAD_PAYMENT_PROOF, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_UPLOAD_PAYMENT_PROOF, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bc *BookingController) RejectPaymentProof(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.RejectPaymentProofRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.BookingID = bookingID
+
+	result, err := bc.bookingService.RejectPaymentProof(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REJECT_PAYMENT_PROOF, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_REJECT_PAYMENT_PROOF, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bc *BookingController) GetVerificationQueue(ctx *gin.Context) {
+	result, err := bc.bookingService.GetVerificationQueue(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_VERIFICATION_QUEUE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_VERIFICATION_QUEUE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bc *BookingController) GetPaymentProof(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	object, err := bc.bookingService.GetPaymentProof(ctx.Request.Context(), bookingID, ctx.Query("proof_id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_PAYMENT_PROOF, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+		return
+	}
+	defer object.Body.Close()
+
+	ctx.DataFromReader(http.StatusOK, object.Size, object.ContentType, object.Body, map[string]string{
+		"Cache-Control": "private, no-store",
+	})
+}
+
+func (bc *BookingController) GetPaymentProofURL(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := bc.bookingService.GetPaymentProofURL(ctx.Request.Context(), bookingID, ctx.Query("proof_id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_PAYMENT_PROOF, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
 		return
 	}
 
-	pdfBytes, err := utils.GenerateInvoicePDF(booking)
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_PAYMENT_PROOF, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bc *BookingController) CheckInBooking(ctx *gin.Context) {
+	var payload dto.CheckInRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := bc.bookingService.CheckInBooking(ctx.Request.Context(), payload)
 	if err != nil {
-		res := utils.BuildResponseFailed("Failed to generate invoice", err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, constants.ErrDeniedAccess):
+			status = http.StatusForbidden
+		case errors.Is(err, constants.ErrAlreadyCheckedIn), errors.Is(err, constants.ErrCheckInNotAllowed),
+			errors.Is(err, constants.ErrCheckInNotOpen), errors.Is(err, constants.ErrCheckInClosed):
+			status = http.StatusConflict
+		}
+
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_CHECK_IN, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
 		return
 	}
 
-	ctx.Header("Content-Type", "application/pdf")
-	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=invoice-%s.pdf", booking.BookingID))
-	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_CHECK_IN, result)
+	ctx.JSON(http.StatusOK, res)
 }
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/middleware"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/revocation"
+	"fieldreserve/service"
+	"fieldreserve/storage"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// bookingRepositoryStub serves a single booking. Methods the tests don't reach are left
+// to the embedded interface and panic if called.
+type bookingRepositoryStub struct {
+	repository.IBookingRepository
+	booking model.Booking
+}
+
+func (s bookingRepositoryStub) GetBookingByID(ctx context.Context, tx *gorm.DB, bookingID string) (model.Booking, bool, error) {
+	if bookingID != s.booking.BookingID.String() {
+		return model.Booking{}, false, gorm.ErrRecordNotFound
+	}
+	return s.booking, true, nil
+}
+
+func (s bookingRepositoryStub) GetPaymentProofs(ctx context.Context, tx *gorm.DB, bookingID string) ([]model.PaymentProof, error) {
+	return nil, nil
+}
+
+func TestBookingAccessByRequester(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ownerID := uuid.New()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := model.Booking{
+		BookingID:     uuid.New(),
+		UserID:        ownerID,
+		FieldID:       uuid.New(),
+		PaymentMethod: "transfer",
+		BookingDate:   start,
+		StartTime:     start,
+		EndTime:       start.Add(time.Hour),
+		TotalPayment:  100000,
+		Status:        constants.ENUM_STATUS_BOOKING_BOOKED,
+		User:          model.User{UserID: ownerID, Name: "Owner", Email: "owner@example.com"},
+		Field:         model.Field{FieldName: "Lapangan A", FieldAddress: "Jl. Test"},
+	}
+
+	jwtService := service.NewJWTService(revocation.NewMemoryStore())
+	bookingService := service.NewBookingService(bookingRepositoryStub{booking: booking}, jwtService, nil, nil, nil, nil, nil, nil, nil, storage.NewLocalStorage(t.TempDir()))
+	bookingController := NewBookingController(bookingService)
+
+	r := gin.New()
+	user := r.Group("/api/users")
+	user.Use(middleware.Authentication(jwtService))
+	user.GET("booking/:id", bookingController.GetBookingByID)
+	user.GET("/booking/:id/invoice", bookingController.DownloadInvoice)
+
+	tokenFor := func(userID uuid.UUID, role string) string {
+		token, _, err := jwtService.GenerateToken(userID.String(), role, uuid.NewString())
+		if err != nil {
+			t.Fatalf("generate token: %v", err)
+		}
+		return token
+	}
+
+	requesters := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"owner", tokenFor(ownerID, constants.ENUM_ROLE_USER), http.StatusOK},
+		{"other user", tokenFor(uuid.New(), constants.ENUM_ROLE_USER), http.StatusForbidden},
+		{"admin", tokenFor(uuid.New(), constants.ENUM_ROLE_ADMIN), http.StatusOK},
+	}
+	paths := []string{
+		"/api/users/booking/" + booking.BookingID.String(),
+		"/api/users/booking/" + booking.BookingID.String() + "/invoice",
+	}
+
+	for _, requester := range requesters {
+		for _, path := range paths {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Authorization", "Bearer "+requester.token)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != requester.want {
+				t.Errorf("%s GET %s: got status %d, want %d (body: %s)", requester.name, path, rec.Code, requester.want, rec.Body.String())
+			}
+		}
+	}
+}
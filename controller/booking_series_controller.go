@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/service"
+	"fieldreserve/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type (
+	IBookingSeriesController interface {
+		CreateBookingSeries(ctx *gin.Context)
+		GetBookingSeriesByID(ctx *gin.Context)
+		UpdateBookingSeries(ctx *gin.Context)
+		UpdateSeriesOccurrence(ctx *gin.Context)
+		CancelBookingSeries(ctx *gin.Context)
+		CancelSeriesOccurrence(ctx *gin.Context)
+	}
+
+	BookingSeriesController struct {
+		bookingSeriesService service.IBookingSeriesService
+	}
+)
+
+func NewBookingSeriesController(bookingSeriesService service.IBookingSeriesService) *BookingSeriesController {
+	return &BookingSeriesController{
+		bookingSeriesService: bookingSeriesService,
+	}
+}
+
+func (bsc *BookingSeriesController) CreateBookingSeries(ctx *gin.Context) {
+	var payload dto.CreateBookingSeriesRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := bsc.bookingSeriesService.CreateBookingSeries(ctx.Request.Context(), payload)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrEmailNotVerified) {
+			status = http.StatusForbidden
+		}
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_CREATE_BOOKING_SERIES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_CREATE_BOOKING_SERIES, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (bsc *BookingSeriesController) GetBookingSeriesByID(ctx *gin.Context) {
+	seriesID := ctx.Param("id")
+
+	if _, err := uuid.Parse(seriesID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := bsc.bookingSeriesService.GetBookingSeriesByID(ctx.Request.Context(), seriesID)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_BOOKING_SERIES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_BOOKING_SERIES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bsc *BookingSeriesController) UpdateBookingSeries(ctx *gin.Context) {
+	seriesID := ctx.Param("id")
+
+	if _, err := uuid.Parse(seriesID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.UpdateBookingSeriesRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.SeriesID = seriesID
+
+	result, err := bsc.bookingSeriesService.UpdateBookingSeries(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UPDATE_BOOKING_SERIES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_UPDATE_BOOKING_SERIES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bsc *BookingSeriesController) UpdateSeriesOccurrence(ctx *gin.Context) {
+	seriesID := ctx.Param("id")
+	bookingID := ctx.Param("booking_id")
+
+	if _, err := uuid.Parse(seriesID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.UpdateSeriesOccurrenceRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.SeriesID = seriesID
+	payload.BookingID = bookingID
+
+	result, err := bsc.bookingSeriesService.UpdateSeriesOccurrence(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UPDATE_BOOKING, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_UPDATE_BOOKING, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bsc *BookingSeriesController) CancelBookingSeries(ctx *gin.Context) {
+	seriesID := ctx.Param("id")
+
+	if _, err := uuid.Parse(seriesID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.CancelBookingSeriesRequest
+	payload.SeriesID = seriesID
+
+	result, err := bsc.bookingSeriesService.CancelBookingSeries(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_CANCEL_BOOKING_SERIES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_CANCEL_BOOKING_SERIES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (bsc *BookingSeriesController) CancelSeriesOccurrence(ctx *gin.Context) {
+	seriesID := ctx.Param("id")
+	bookingID := ctx.Param("booking_id")
+
+	if _, err := uuid.Parse(seriesID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.CancelSeriesOccurrenceRequest
+	payload.SeriesID = seriesID
+	payload.BookingID = bookingID
+
+	result, err := bsc.bookingSeriesService.CancelSeriesOccurrence(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UPDATE_BOOKING, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_UPDATE_BOOKING, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"fieldreserve/constants"
 	"fieldreserve/dto"
 	"fieldreserve/service"
@@ -18,6 +19,9 @@ type (
 		GetFieldByID(ctx *gin.Context)
 		UpdateField(ctx *gin.Context)
 		DeleteField(ctx *gin.Context)
+		AddFieldImage(ctx *gin.Context)
+		ReorderFieldImages(ctx *gin.Context)
+		DeleteFieldImage(ctx *gin.Context)
 	}
 
 	FieldController struct {
@@ -144,3 +148,93 @@ func (fc *FieldController) DeleteField(ctx *gin.Context) {
 	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_DELETE_FIELD, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (fc *FieldController) AddFieldImage(ctx *gin.Context) {
+	fieldID := ctx.Param("id")
+
+	if _, err := uuid.Parse(fieldID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.AddFieldImageRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.FieldID = fieldID
+
+	result, err := fc.fieldService.AddFieldImage(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_ADD_FIELD_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_ADD_FIELD_IMAGE, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (fc *FieldController) ReorderFieldImages(ctx *gin.Context) {
+	fieldID := ctx.Param("id")
+
+	if _, err := uuid.Parse(fieldID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.ReorderFieldImagesRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.FieldID = fieldID
+
+	result, err := fc.fieldService.ReorderFieldImages(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REORDER_FIELD_IMAGES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_REORDER_FIELD_IMAGES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (fc *FieldController) DeleteFieldImage(ctx *gin.Context) {
+	fieldID := ctx.Param("id")
+	imageID := ctx.Param("image_id")
+
+	if _, err := uuid.Parse(fieldID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if _, err := uuid.Parse(imageID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := fc.fieldService.DeleteFieldImage(ctx.Request.Context(), dto.DeleteFieldImageRequest{
+		FieldID: fieldID,
+		ImageID: imageID,
+	})
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrFieldImageNotFound) {
+			status = http.StatusNotFound
+		}
+
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_DELETE_FIELD_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_DELETE_FIELD_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/service"
+	"fieldreserve/storage"
+	"fieldreserve/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IFileController interface {
+		GetFieldImage(ctx *gin.Context)
+		GetSignedFile(ctx *gin.Context)
+	}
+
+	FileController struct {
+		fileService service.IFileService
+	}
+)
+
+func NewFileController(fileService service.IFileService) *FileController {
+	return &FileController{
+		fileService: fileService,
+	}
+}
+
+func (fc *FileController) GetFieldImage(ctx *gin.Context) {
+	object, err := fc.fileService.GetFieldImage(ctx.Request.Context(), ctx.Param("name"))
+	if err != nil {
+		fc.abort(ctx, err)
+		return
+	}
+	defer object.Body.Close()
+
+	ctx.DataFromReader(http.StatusOK, object.Size, object.ContentType, object.Body, map[string]string{
+		"Cache-Control": "public, max-age=86400",
+	})
+}
+
+func (fc *FileController) GetSignedFile(ctx *gin.Context) {
+	var payload dto.SignedFileRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.Key = strings.TrimPrefix(ctx.Param("key"), "/")
+
+	object, err := fc.fileService.GetSignedFile(ctx.Request.Context(), payload)
+	if err != nil {
+		fc.abort(ctx, err)
+		return
+	}
+	defer object.Body.Close()
+
+	ctx.DataFromReader(http.StatusOK, object.Size, object.ContentType, object.Body, map[string]string{
+		"Cache-Control": "private, no-store",
+	})
+}
+
+func (fc *FileController) abort(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, constants.ErrFileNotFound), errors.Is(err, storage.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, constants.ErrInvalidSignedURL):
+		status = http.StatusForbidden
+	}
+
+	res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_FILE, err.Error(), nil)
+	ctx.AbortWithStatusJSON(status, res)
+}
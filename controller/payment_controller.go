@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/service"
+	"fieldreserve/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type (
+	IPaymentController interface {
+		CreatePayment(ctx *gin.Context)
+		GetBookingPayments(ctx *gin.Context)
+		SimulatePayment(ctx *gin.Context)
+		RefundPayment(ctx *gin.Context)
+		HandleWebhook(ctx *gin.Context)
+	}
+
+	PaymentController struct {
+		paymentService service.IPaymentService
+	}
+)
+
+func NewPaymentController(paymentService service.IPaymentService) *PaymentController {
+	return &PaymentController{
+		paymentService: paymentService,
+	}
+}
+
+func (pc *PaymentController) CreatePayment(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := pc.paymentService.CreatePayment(ctx.Request.Context(), dto.CreatePaymentRequest{BookingID: bookingID})
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_CREATE_PAYMENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_CREATE_PAYMENT, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (pc *PaymentController) GetBookingPayments(ctx *gin.Context) {
+	bookingID := ctx.Param("id")
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := pc.paymentService.GetBookingPayments(ctx.Request.Context(), bookingID)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_PAYMENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_PAYMENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (pc *PaymentController) SimulatePayment(ctx *gin.Context) {
+	paymentID := ctx.Param("id")
+
+	if _, err := uuid.Parse(paymentID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.SimulatePaymentRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.PaymentID = paymentID
+
+	result, err := pc.paymentService.SimulatePayment(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_SIMULATE_PAYMENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_SIMULATE_PAYMENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (pc *PaymentController) RefundPayment(ctx *gin.Context) {
+	paymentID := ctx.Param("id")
+
+	if _, err := uuid.Parse(paymentID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.RefundPaymentRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.PaymentID = paymentID
+
+	result, err := pc.paymentService.RefundPayment(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REFUND_PAYMENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_REFUND_PAYMENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// HandleWebhook receives gateway callbacks. The raw body is passed on untouched because
+// the signature is computed over the exact bytes the provider sent.
+func (pc *PaymentController) HandleWebhook(ctx *gin.Context) {
+	payload, err := ctx.GetRawData()
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	err = pc.paymentService.HandleWebhook(ctx.Request.Context(), dto.PaymentWebhookRequest{
+		Provider:  ctx.Param("provider"),
+		Payload:   payload,
+		Signature: ctx.GetHeader("X-Signature"),
+	})
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, constants.ErrInvalidWebhookSignature):
+			status = http.StatusUnauthorized
+		case errors.Is(err, constants.ErrUnknownPaymentProvider), errors.Is(err, constants.ErrPaymentNotFound):
+			status = http.StatusNotFound
+		}
+
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_PAYMENT_WEBHOOK, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_PAYMENT_WEBHOOK, nil)
+	ctx.JSON(http.StatusOK, res)
+}
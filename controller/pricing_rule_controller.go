@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/service"
+	"fieldreserve/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type (
+	IPricingRuleController interface {
+		CreatePricingRule(ctx *gin.Context)
+		GetPricingRuleByID(ctx *gin.Context)
+		GetPricingRulesByFieldID(ctx *gin.Context)
+		UpdatePricingRule(ctx *gin.Context)
+		DeletePricingRule(ctx *gin.Context)
+	}
+
+	PricingRuleController struct {
+		pricingRuleService service.IPricingRuleService
+	}
+)
+
+func NewPricingRuleController(pricingRuleService service.IPricingRuleService) *PricingRuleController {
+	return &PricingRuleController{
+		pricingRuleService: pricingRuleService,
+	}
+}
+
+func (prc *PricingRuleController) CreatePricingRule(ctx *gin.Context) {
+	var payload dto.CreatePricingRuleRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := prc.pricingRuleService.CreatePricingRule(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_CREATE_PRICING_RULE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_CREATE_PRICING_RULE, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (prc *PricingRuleController) GetPricingRuleByID(ctx *gin.Context) {
+	ruleID := ctx.Param("id")
+
+	if _, err := uuid.Parse(ruleID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := prc.pricingRuleService.GetPricingRuleByID(ctx.Request.Context(), ruleID)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_PRICING_RULE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_PRICING_RULE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (prc *PricingRuleController) GetPricingRulesByFieldID(ctx *gin.Context) {
+	fieldID := ctx.Param("field_id")
+
+	if _, err := uuid.Parse(fieldID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := prc.pricingRuleService.GetPricingRulesByFieldID(ctx.Request.Context(), fieldID)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_PRICING_RULE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_PRICING_RULE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (prc *PricingRuleController) UpdatePricingRule(ctx *gin.Context) {
+	ruleID := ctx.Param("id")
+
+	if _, err := uuid.Parse(ruleID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.UpdatePricingRuleRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.PricingRuleID = ruleID
+
+	result, err := prc.pricingRuleService.UpdatePricingRule(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UPDATE_PRICING_RULE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_UPDATE_PRICING_RULE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (prc *PricingRuleController) DeletePricingRule(ctx *gin.Context) {
+	ruleID := ctx.Param("id")
+
+	if _, err := uuid.Parse(ruleID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.DeletePricingRuleRequest
+	payload.PricingRuleID = ruleID
+
+	result, err := prc.pricingRuleService.DeletePricingRule(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_DELETE_PRICING_RULE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_DELETE_PRICING_RULE, result)
+	ctx.JSON(http.StatusOK, res)
+}
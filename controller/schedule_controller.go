@@ -21,6 +21,7 @@ type (
 		GetScheduleByID(ctx *gin.Context)
 		GetSchedulesByFieldID(ctx *gin.Context)
 		GetScheduleByFieldIDAndDay(ctx *gin.Context)
+		GetFieldAvailability(ctx *gin.Context)
 	}
 
 	ScheduleController struct {
@@ -191,4 +192,32 @@ func (sc *ScheduleController) GetScheduleByFieldIDAndDay(ctx *gin.Context) {
 
 	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_DETAIL_SCHEDULE, result)
 	ctx.JSON(http.StatusOK, res)
-}
\ No newline at end of file
+}
+
+func (sc *ScheduleController) GetFieldAvailability(ctx *gin.Context) {
+	fieldID := ctx.Param("field_id")
+
+	if _, err := uuid.Parse(fieldID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.FieldAvailabilityRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.FieldID = fieldID
+
+	result, err := sc.scheduleService.GetFieldAvailability(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_AVAILABILITY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_AVAILABILITY, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"fieldreserve/constants"
 	"fieldreserve/dto"
 	"fieldreserve/service"
@@ -15,9 +16,19 @@ type (
 	IUserController interface {
 		CreateUser(ctx *gin.Context)
 		GetUserByEmail(ctx *gin.Context)
+		RefreshToken(ctx *gin.Context)
+		Logout(ctx *gin.Context)
+		LogoutAll(ctx *gin.Context)
+		GetSessions(ctx *gin.Context)
+		RevokeUserSessions(ctx *gin.Context)
+		ForgotPassword(ctx *gin.Context)
+		ResetPassword(ctx *gin.Context)
+		VerifyEmail(ctx *gin.Context)
+		ResendEmailVerification(ctx *gin.Context)
 		GetUserByID(ctx *gin.Context)
 		GetAllUser(ctx *gin.Context)
 		UpdateUser(ctx *gin.Context)
+		UpdateUserRole(ctx *gin.Context)
 		DeleteUser(ctx *gin.Context)
 	}
 
@@ -59,6 +70,9 @@ func (uh *UserController) GetUserByEmail(ctx *gin.Context) {
 		return
 	}
 
+	payload.UserAgent = ctx.Request.UserAgent()
+	payload.IPAddress = ctx.ClientIP()
+
 	result, err := uh.userService.GetUserByEmail(ctx, payload)
 	if err != nil {
 		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LOGIN_USER, err.Error(), nil)
@@ -70,13 +84,182 @@ func (uh *UserController) GetUserByEmail(ctx *gin.Context) {
 	ctx.AbortWithStatusJSON(http.StatusOK, res)
 }
 
+func (uh *UserController) RefreshToken(ctx *gin.Context) {
+	var payload dto.RefreshTokenRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	payload.UserAgent = ctx.Request.UserAgent()
+	payload.IPAddress = ctx.ClientIP()
+
+	result, err := uh.userService.RefreshToken(ctx, payload)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidRefreshToken) || errors.Is(err, constants.ErrRefreshTokenReused) {
+			status = http.StatusUnauthorized
+		}
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REFRESH_TOKEN, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_REFRESH_TOKEN, result)
+	ctx.AbortWithStatusJSON(http.StatusOK, res)
+}
+
+func (uh *UserController) Logout(ctx *gin.Context) {
+	if err := uh.userService.Logout(ctx.Request.Context()); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LOGOUT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_LOGOUT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (uh *UserController) LogoutAll(ctx *gin.Context) {
+	result, err := uh.userService.LogoutAll(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LOGOUT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_LOGOUT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (uh *UserController) GetSessions(ctx *gin.Context) {
+	result, err := uh.userService.GetSessions(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_SESSIONS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_SESSIONS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (uh *UserController) RevokeUserSessions(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+
+	if _, err := uuid.Parse(idStr); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := uh.userService.RevokeUserSessions(ctx.Request.Context(), idStr)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrGetUserByID) {
+			status = http.StatusNotFound
+		}
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REVOKE_SESSIONS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_REVOKE_SESSIONS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (uh *UserController) ForgotPassword(ctx *gin.Context) {
+	var payload dto.ForgotPasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := uh.userService.ForgotPassword(ctx.Request.Context(), payload); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidEmail) {
+			status = http.StatusBadRequest
+		}
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_FORGOT_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_FORGOT_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (uh *UserController) ResetPassword(ctx *gin.Context) {
+	var payload dto.ResetPasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := uh.userService.ResetPassword(ctx.Request.Context(), payload); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidPassword) || errors.Is(err, constants.ErrInvalidResetToken) {
+			status = http.StatusBadRequest
+		}
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_RESET_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_RESET_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (uh *UserController) VerifyEmail(ctx *gin.Context) {
+	var payload dto.VerifyEmailRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := uh.userService.VerifyEmail(ctx.Request.Context(), payload); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidVerificationToken) {
+			status = http.StatusBadRequest
+		}
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_VERIFY_EMAIL, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_VERIFY_EMAIL, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (uh *UserController) ResendEmailVerification(ctx *gin.Context) {
+	if err := uh.userService.ResendEmailVerification(ctx.Request.Context()); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, constants.ErrVerificationRateLimited):
+			status = http.StatusTooManyRequests
+		case errors.Is(err, constants.ErrEmailAlreadyVerified):
+			status = http.StatusConflict
+		}
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_RESEND_VERIFICATION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_RESEND_VERIFICATION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (uc *UserController) GetUserByID(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 
 	userID := ctx.GetString("user_id")
 	role := ctx.GetString("role")
 
-	if role == constants.ENUM_ROLE_USER && userID != idStr {
+	if role != constants.ENUM_ROLE_ADMIN && userID != idStr {
 		res := utils.BuildResponseFailed("unauthorized", "you can only get your own account", nil)
 		ctx.AbortWithStatusJSON(http.StatusForbidden, res)
 		return
@@ -130,7 +313,7 @@ func (uh *UserController) UpdateUser(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	role := ctx.GetString("role")
 
-	if role == constants.ENUM_ROLE_USER && userID != idStr {
+	if role != constants.ENUM_ROLE_ADMIN && userID != idStr {
 		res := utils.BuildResponseFailed("unauthorized", "you can only update your own account", nil)
 		ctx.AbortWithStatusJSON(http.StatusForbidden, res)
 		return
@@ -156,13 +339,41 @@ func (uh *UserController) UpdateUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (uh *UserController) UpdateUserRole(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+
+	if _, err := uuid.Parse(idStr); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.UpdateUserRoleRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.UserID = idStr
+
+	result, err := uh.userService.UpdateUserRole(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UPDATE_USER_ROLE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_UPDATE_USER_ROLE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (uh *UserController) DeleteUser(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 
 	userID := ctx.GetString("user_id")
 	role := ctx.GetString("role")
 
-	if role == constants.ENUM_ROLE_USER && userID != idStr {
+	if role != constants.ENUM_ROLE_ADMIN && userID != idStr {
 		res := utils.BuildResponseFailed("unauthorized", "you can only delete your own account", nil)
 		ctx.AbortWithStatusJSON(http.StatusForbidden, res)
 		return
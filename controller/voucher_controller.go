@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/service"
+	"fieldreserve/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type (
+	IVoucherController interface {
+		CreateVoucher(ctx *gin.Context)
+		GetVoucherByID(ctx *gin.Context)
+		GetAllVouchers(ctx *gin.Context)
+		UpdateVoucher(ctx *gin.Context)
+		DeleteVoucher(ctx *gin.Context)
+	}
+
+	VoucherController struct {
+		voucherService service.IVoucherService
+	}
+)
+
+func NewVoucherController(voucherService service.IVoucherService) *VoucherController {
+	return &VoucherController{
+		voucherService: voucherService,
+	}
+}
+
+func (vc *VoucherController) CreateVoucher(ctx *gin.Context) {
+	var payload dto.CreateVoucherRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := vc.voucherService.CreateVoucher(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_CREATE_VOUCHER, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_CREATE_VOUCHER, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (vc *VoucherController) GetVoucherByID(ctx *gin.Context) {
+	voucherID := ctx.Param("id")
+
+	if _, err := uuid.Parse(voucherID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := vc.voucherService.GetVoucherByID(ctx.Request.Context(), voucherID)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_VOUCHER, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_VOUCHER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (vc *VoucherController) GetAllVouchers(ctx *gin.Context) {
+	result, err := vc.voucherService.GetAllVouchers(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_VOUCHER, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_VOUCHER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (vc *VoucherController) UpdateVoucher(ctx *gin.Context) {
+	voucherID := ctx.Param("id")
+
+	if _, err := uuid.Parse(voucherID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.UpdateVoucherRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.VoucherID = voucherID
+
+	result, err := vc.voucherService.UpdateVoucher(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UPDATE_VOUCHER, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_UPDATE_VOUCHER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (vc *VoucherController) DeleteVoucher(ctx *gin.Context) {
+	voucherID := ctx.Param("id")
+
+	if _, err := uuid.Parse(voucherID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var payload dto.DeleteVoucherRequest
+	payload.VoucherID = voucherID
+
+	result, err := vc.voucherService.DeleteVoucher(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_DELETE_VOUCHER, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_DELETE_VOUCHER, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/service"
+	"fieldreserve/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type (
+	IWaitlistController interface {
+		JoinWaitlist(ctx *gin.Context)
+		GetMyWaitlist(ctx *gin.Context)
+		LeaveWaitlist(ctx *gin.Context)
+		GetWaitlistDemand(ctx *gin.Context)
+	}
+
+	WaitlistController struct {
+		waitlistService service.IWaitlistService
+	}
+)
+
+func NewWaitlistController(waitlistService service.IWaitlistService) *WaitlistController {
+	return &WaitlistController{
+		waitlistService: waitlistService,
+	}
+}
+
+func (wc *WaitlistController) JoinWaitlist(ctx *gin.Context) {
+	var payload dto.JoinWaitlistRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := wc.waitlistService.JoinWaitlist(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_JOIN_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_JOIN_WAITLIST, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (wc *WaitlistController) GetMyWaitlist(ctx *gin.Context) {
+	result, err := wc.waitlistService.GetMyWaitlist(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_WAITLIST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (wc *WaitlistController) LeaveWaitlist(ctx *gin.Context) {
+	waitlistID := ctx.Param("id")
+
+	if _, err := uuid.Parse(waitlistID); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_UUID_FORMAT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := wc.waitlistService.LeaveWaitlist(ctx.Request.Context(), waitlistID)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LEAVE_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_LEAVE_WAITLIST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (wc *WaitlistController) GetWaitlistDemand(ctx *gin.Context) {
+	var payload dto.WaitlistDemandRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := wc.waitlistService.GetWaitlistDemand(ctx.Request.Context(), payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_WAITLIST_DEMAND, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_WAITLIST_DEMAND, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -15,24 +15,32 @@ type (
 		StartTime     string                `form:"start_time" binding:"required"`
 		EndTime       string                `form:"end_time" binding:"required"`
 		PaymentMethod string                `form:"payment_method" binding:"required"`
-		ProofPayment  *multipart.FileHeader `form:"proof_payment" binding:"required"`
-		TotalPayment  float64               `form:"total_payment" binding:"required"`
+		ProofPayment  *multipart.FileHeader `form:"proof_payment"`
+		TotalPayment  float64               `form:"total_payment"`
+		PromoCode     string                `form:"promo_code"`
 	}
 
 	BookingResponse struct {
-		BookingID         uuid.UUID  `json:"booking_id"`
-		UserID            uuid.UUID  `json:"user_id"`
-		FieldID           uuid.UUID  `json:"field_id"`
-		PaymentMethod     string     `json:"payment_method"`
-		BookingDate       time.Time  `json:"booking_date"`
-		StartTime         time.Time  `json:"start_time"`
-		EndTime           time.Time  `json:"end_time"`
-		Status            string     `json:"status"`
-		TotalPayment      float64    `json:"total_payment"`
-		ProofPayment      string     `json:"proof_payment"`
-		PaymentUploadedAt *time.Time `json:"payment_uploaded_at,omitempty"`
-		PaymentVerifiedAt *time.Time `json:"payment_verified_at,omitempty"`
-		CancelledAt       *time.Time `json:"cancelled_at,omitempty"`
+		BookingID         uuid.UUID           `json:"booking_id"`
+		UserID            uuid.UUID           `json:"user_id"`
+		FieldID           uuid.UUID           `json:"field_id"`
+		SeriesID          *uuid.UUID          `json:"series_id,omitempty"`
+		PaymentMethod     string              `json:"payment_method"`
+		BookingDate       time.Time           `json:"booking_date"`
+		StartTime         time.Time           `json:"start_time"`
+		EndTime           time.Time           `json:"end_time"`
+		Status            string              `json:"status"`
+		TotalPayment      float64             `json:"total_payment"`
+		ProofPayment      string              `json:"proof_payment"`
+		PaymentDueAt      *time.Time          `json:"payment_due_at,omitempty"`
+		PaymentUploadedAt *time.Time          `json:"payment_uploaded_at,omitempty"`
+		PaymentVerifiedAt *time.Time          `json:"payment_verified_at,omitempty"`
+		CancelledAt       *time.Time          `json:"cancelled_at,omitempty"`
+		CancelReason      string              `json:"cancel_reason,omitempty"`
+		RefundAmount      float64             `json:"refund_amount,omitempty"`
+		PaymentAdjustment float64             `json:"payment_adjustment,omitempty"`
+		RescheduleCount   int                 `json:"reschedule_count"`
+		PriceItems        []PriceItemResponse `json:"price_items,omitempty"`
 	}
 
 	UserCompactResponse struct {
@@ -44,25 +52,125 @@ type (
 	}
 
 	BookingFullResponse struct {
-		BookingID         uuid.UUID            `json:"booking_id"`
-		PaymentMethod     string               `json:"payment_method"`
-		BookingDate       time.Time            `json:"booking_date"`
-		StartTime         time.Time            `json:"start_time"`
-		EndTime           time.Time            `json:"end_time"`
-		TotalPayment      float64              `json:"total_payment"`
-		ProofPayment      string               `json:"proof_payment"`
-		Status            string               `json:"status"`
-		User              UserCompactResponse  `json:"user"`
-		Field             FieldCompactResponse `json:"field"`
-		PaymentVerifiedAt *time.Time           `json:"payment_verified_at,omitempty"`
-		CancelledAt       *time.Time           `json:"cancelled_at,omitempty"`
-		PaymentUploadedAt *time.Time           `json:"payment_uploaded_at,omitempty"`
-		VerifiedAt        *time.Time           `json:"verified_at,omitempty"`
+		BookingID         uuid.UUID                  `json:"booking_id"`
+		SeriesID          *uuid.UUID                 `json:"series_id,omitempty"`
+		PaymentMethod     string                     `json:"payment_method"`
+		BookingDate       time.Time                  `json:"booking_date"`
+		StartTime         time.Time                  `json:"start_time"`
+		EndTime           time.Time                  `json:"end_time"`
+		TotalPayment      float64                    `json:"total_payment"`
+		ProofPayment      string                     `json:"proof_payment"`
+		Status            string                     `json:"status"`
+		User              UserCompactResponse        `json:"user"`
+		Field             FieldCompactResponse       `json:"field"`
+		PaymentVerifiedAt *time.Time                 `json:"payment_verified_at,omitempty"`
+		CancelledAt       *time.Time                 `json:"cancelled_at,omitempty"`
+		CancelReason      string                     `json:"cancel_reason,omitempty"`
+		RefundAmount      float64                    `json:"refund_amount,omitempty"`
+		PaymentAdjustment float64                    `json:"payment_adjustment,omitempty"`
+		RescheduleCount   int                        `json:"reschedule_count"`
+		PaymentDueAt      *time.Time                 `json:"payment_due_at,omitempty"`
+		PaymentUploadedAt *time.Time                 `json:"payment_uploaded_at,omitempty"`
+		VerifiedAt        *time.Time                 `json:"verified_at,omitempty"`
+		CheckedInAt       *time.Time                 `json:"checked_in_at,omitempty"`
+		PriceItems        []PriceItemResponse        `json:"price_items"`
+		Voucher           *VoucherRedemptionResponse `json:"voucher,omitempty"`
+		ProofHistory      []PaymentProofResponse     `json:"proof_history,omitempty"`
 	}
 
 	UpdateBookingStatusRequest struct {
 		BookingID string  `json:"-"`
-		Status    *string `json:"status,omitempty"`
+		Status    *string `json:"status,omitempty" binding:"required"`
+		Reason    string  `json:"reason"`
+	}
+
+	BookingStatusHistoryResponse struct {
+		FromStatus string     `json:"from_status,omitempty"`
+		ToStatus   string     `json:"to_status"`
+		ChangedBy  *uuid.UUID `json:"changed_by,omitempty"`
+		ActorRole  string     `json:"actor_role"`
+		Reason     string     `json:"reason,omitempty"`
+		ChangedAt  time.Time  `json:"changed_at"`
+	}
+
+	BookingTimelineResponse struct {
+		BookingID     uuid.UUID                      `json:"booking_id"`
+		CurrentStatus string                         `json:"current_status"`
+		History       []BookingStatusHistoryResponse `json:"history"`
+	}
+
+	RescheduleBookingRequest struct {
+		BookingID   string `json:"-"`
+		FieldID     string `json:"field_id"`
+		BookingDate string `json:"booking_date" binding:"required"`
+		StartTime   string `json:"start_time" binding:"required"`
+		EndTime     string `json:"end_time" binding:"required"`
+	}
+
+	RescheduleBookingResponse struct {
+		Booking         BookingResponse `json:"booking"`
+		PreviousTotal   float64         `json:"previous_total"`
+		PriceDifference float64         `json:"price_difference"`
+		// VoucherRemoved is set when the promo code used at checkout does not cover the new slot.
+		VoucherRemoved bool `json:"voucher_removed"`
+	}
+
+	CancelBookingRequest struct {
+		BookingID string `json:"-"`
+	}
+
+	UploadPaymentProofRequest struct {
+		BookingID    string                `form:"-"`
+		ProofPayment *multipart.FileHeader `form:"proof_payment" binding:"required"`
+	}
+
+	PaymentProofResponse struct {
+		ProofID      uuid.UUID  `json:"proof_id"`
+		FileName     string     `json:"file_name"`
+		UploadedBy   uuid.UUID  `json:"uploaded_by"`
+		UploadedAt   time.Time  `json:"uploaded_at"`
+		RejectedAt   *time.Time `json:"rejected_at,omitempty"`
+		RejectReason string     `json:"reject_reason,omitempty"`
+	}
+
+	SignedURLResponse struct {
+		URL       string    `json:"url"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	RejectPaymentProofRequest struct {
+		BookingID string `json:"-"`
+		Reason    string `json:"reason" binding:"required"`
+	}
+
+	VerificationQueueItem struct {
+		BookingID         uuid.UUID  `json:"booking_id"`
+		UserName          string     `json:"user_name"`
+		UserEmail         string     `json:"user_email"`
+		FieldName         string     `json:"field_name"`
+		BookingDate       time.Time  `json:"booking_date"`
+		StartTime         time.Time  `json:"start_time"`
+		EndTime           time.Time  `json:"end_time"`
+		PaymentMethod     string     `json:"payment_method"`
+		ExpectedAmount    float64    `json:"expected_amount"`
+		PaymentAdjustment float64    `json:"payment_adjustment,omitempty"`
+		ProofURL          string     `json:"proof_url"`
+		PaymentUploadedAt *time.Time `json:"payment_uploaded_at"`
+		Overdue           bool       `json:"overdue"`
+	}
+
+	// CheckInRequest carries the token scanned from the invoice QR code.
+	CheckInRequest struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	CheckInResponse struct {
+		BookingID   uuid.UUID `json:"booking_id"`
+		UserName    string    `json:"user_name"`
+		FieldName   string    `json:"field_name"`
+		StartTime   time.Time `json:"start_time"`
+		EndTime     time.Time `json:"end_time"`
+		CheckedInAt time.Time `json:"checked_in_at"`
 	}
 
 	DeleteBookingRequest struct {
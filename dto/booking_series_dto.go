@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	CreateBookingSeriesRequest struct {
+		FieldID       string `json:"field_id" binding:"required"`
+		StartDate     string `json:"start_date" binding:"required"`
+		StartTime     string `json:"start_time" binding:"required"`
+		EndTime       string `json:"end_time" binding:"required"`
+		IntervalWeeks int    `json:"interval_weeks" binding:"omitempty,min=1,max=12"`
+		UntilDate     string `json:"until_date"`
+		Count         int    `json:"count" binding:"omitempty,min=1"`
+		PaymentMethod string `json:"payment_method" binding:"required"`
+	}
+
+	UpdateBookingSeriesRequest struct {
+		SeriesID  string `json:"-"`
+		StartTime string `json:"start_time" binding:"required"`
+		EndTime   string `json:"end_time" binding:"required"`
+	}
+
+	UpdateSeriesOccurrenceRequest struct {
+		SeriesID    string `json:"-"`
+		BookingID   string `json:"-"`
+		BookingDate string `json:"booking_date"`
+		StartTime   string `json:"start_time" binding:"required"`
+		EndTime     string `json:"end_time" binding:"required"`
+	}
+
+	CancelBookingSeriesRequest struct {
+		SeriesID string `json:"-"`
+	}
+
+	CancelSeriesOccurrenceRequest struct {
+		SeriesID  string `json:"-"`
+		BookingID string `json:"-"`
+	}
+
+	BookingSeriesResponse struct {
+		SeriesID        uuid.UUID         `json:"series_id"`
+		UserID          uuid.UUID         `json:"user_id"`
+		FieldID         uuid.UUID         `json:"field_id"`
+		PaymentMethod   string            `json:"payment_method"`
+		IntervalWeeks   int               `json:"interval_weeks"`
+		DayOfWeek       int               `json:"day_of_week"`
+		DayName         string            `json:"day_name"`
+		StartTime       string            `json:"start_time"`
+		EndTime         string            `json:"end_time"`
+		StartDate       string            `json:"start_date"`
+		UntilDate       string            `json:"until_date,omitempty"`
+		OccurrenceCount int               `json:"occurrence_count,omitempty"`
+		Status          string            `json:"status"`
+		CancelledAt     *time.Time        `json:"cancelled_at,omitempty"`
+		Bookings        []BookingResponse `json:"bookings,omitempty"`
+	}
+
+	SeriesOccurrenceResult struct {
+		BookingDate  string     `json:"booking_date"`
+		BookingID    *uuid.UUID `json:"booking_id,omitempty"`
+		TotalPayment float64    `json:"total_payment,omitempty"`
+		Reason       string     `json:"reason,omitempty"`
+	}
+
+	BookingSeriesResultResponse struct {
+		Series    BookingSeriesResponse    `json:"series"`
+		Succeeded []SeriesOccurrenceResult `json:"succeeded"`
+		Conflicts []SeriesOccurrenceResult `json:"conflicts"`
+	}
+)
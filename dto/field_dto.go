@@ -22,13 +22,22 @@ type (
 		Description string    `json:"description"`
 	}
 
+	// FieldImageURLs points at the stored photo and each of its resized variants.
+	FieldImageURLs struct {
+		Original string `json:"original"`
+		Thumb    string `json:"thumb"`
+		Medium   string `json:"medium"`
+		Large    string `json:"large"`
+	}
+
 	FieldResponse struct {
-		FieldID      uuid.UUID `json:"field_id"`
-		FieldName    string    `json:"field_name"`
-		FieldAddress string    `json:"field_address"`
-		FieldPrice   int       `json:"field_price"`
-		FieldImage   string    `json:"field_image"`
-		CategoryID   uuid.UUID `json:"category_id"`
+		FieldID      uuid.UUID       `json:"field_id"`
+		FieldName    string          `json:"field_name"`
+		FieldAddress string          `json:"field_address"`
+		FieldPrice   int             `json:"field_price"`
+		FieldImage   string          `json:"field_image"`
+		ImageURLs    *FieldImageURLs `json:"image_urls"`
+		CategoryID   uuid.UUID       `json:"category_id"`
 	}
 
 	FieldFullResponse struct {
@@ -37,6 +46,8 @@ type (
 		FieldAddress string                  `json:"field_address"`
 		FieldPrice   int                     `json:"field_price"`
 		FieldImage   string                  `json:"field_image"`
+		ImageURLs    *FieldImageURLs         `json:"image_urls"`
+		Images       []FieldImageResponse    `json:"images"`
 		Category     CategoryCompactResponse `json:"category"`
 	}
 
@@ -51,6 +62,35 @@ type (
 		FieldID string `json:"-"`
 	}
 
+	// Gallery
+	AddFieldImageRequest struct {
+		FieldID string                `form:"-"`
+		Image   *multipart.FileHeader `form:"image" binding:"required"`
+		Caption string                `form:"caption" binding:"max=255"`
+		IsCover bool                  `form:"is_cover"`
+	}
+
+	// ReorderFieldImagesRequest lists every image of the field in its new order.
+	ReorderFieldImagesRequest struct {
+		FieldID      string   `json:"-"`
+		ImageIDs     []string `json:"image_ids" binding:"required,min=1,dive,uuid"`
+		CoverImageID string   `json:"cover_image_id" binding:"omitempty,uuid"`
+	}
+
+	DeleteFieldImageRequest struct {
+		FieldID string `json:"-"`
+		ImageID string `json:"-"`
+	}
+
+	FieldImageResponse struct {
+		ImageID   uuid.UUID       `json:"image_id"`
+		FileName  string          `json:"file_name"`
+		Caption   string          `json:"caption"`
+		SortOrder int             `json:"sort_order"`
+		IsCover   bool            `json:"is_cover"`
+		URLs      *FieldImageURLs `json:"urls"`
+	}
+
 
 	// Pagination
 	FieldPaginationRequest struct {
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	SignedFileRequest struct {
+		Key       string `form:"-"`
+		Expires   string `form:"expires" binding:"required"`
+		Signature string `form:"signature" binding:"required"`
+	}
+)
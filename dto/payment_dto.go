@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	CreatePaymentRequest struct {
+		BookingID string `json:"-"`
+	}
+
+	SimulatePaymentRequest struct {
+		PaymentID string `json:"-"`
+		Status    string `json:"status" binding:"required,oneof=paid failed expired"`
+	}
+
+	RefundPaymentRequest struct {
+		PaymentID string  `json:"-"`
+		Amount    float64 `json:"amount" binding:"min=0"`
+		Reason    string  `json:"reason"`
+	}
+
+	PaymentWebhookRequest struct {
+		Provider  string
+		Payload   []byte
+		Signature string
+	}
+
+	PaymentResponse struct {
+		PaymentID    uuid.UUID  `json:"payment_id"`
+		BookingID    uuid.UUID  `json:"booking_id"`
+		Provider     string     `json:"provider"`
+		ProviderRef  string     `json:"provider_ref"`
+		Amount       float64    `json:"amount"`
+		Status       string     `json:"status"`
+		PaymentURL   string     `json:"payment_url,omitempty"`
+		ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+		PaidAt       *time.Time `json:"paid_at,omitempty"`
+		RefundAmount float64    `json:"refund_amount,omitempty"`
+		RefundedAt   *time.Time `json:"refunded_at,omitempty"`
+	}
+)
@@ -0,0 +1,38 @@
+package dto
+
+import "github.com/google/uuid"
+
+type (
+	QuoteBookingRequest struct {
+		FieldID     string `json:"field_id" binding:"required"`
+		BookingDate string `json:"booking_date" binding:"required"`
+		StartTime   string `json:"start_time" binding:"required"`
+		EndTime     string `json:"end_time" binding:"required"`
+		PromoCode   string `json:"promo_code"`
+	}
+
+	PriceItemResponse struct {
+		ItemType  string  `json:"item_type"`
+		Label     string  `json:"label"`
+		Quantity  float64 `json:"quantity"`
+		UnitPrice float64 `json:"unit_price"`
+		Amount    float64 `json:"amount"`
+	}
+
+	PriceBreakdownResponse struct {
+		Items    []PriceItemResponse `json:"items"`
+		Discount float64             `json:"discount,omitempty"`
+		Total    float64             `json:"total"`
+	}
+
+	QuoteBookingResponse struct {
+		FieldID       uuid.UUID           `json:"field_id"`
+		BookingDate   string              `json:"booking_date"`
+		StartTime     string              `json:"start_time"`
+		EndTime       string              `json:"end_time"`
+		DurationHours float64             `json:"duration_hours"`
+		Items         []PriceItemResponse `json:"items"`
+		Discount      float64             `json:"discount,omitempty"`
+		TotalPayment  float64             `json:"total_payment"`
+	}
+)
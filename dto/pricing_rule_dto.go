@@ -0,0 +1,50 @@
+package dto
+
+import "github.com/google/uuid"
+
+type (
+	CreatePricingRuleRequest struct {
+		FieldID      string `json:"field_id" binding:"required"`
+		Name         string `json:"name" binding:"required"`
+		DayOfWeek    *int   `json:"day_of_week" binding:"omitempty,min=0,max=6"`
+		StartTime    string `json:"start_time" binding:"required"`
+		EndTime      string `json:"end_time" binding:"required"`
+		ValidFrom    string `json:"valid_from"`
+		ValidUntil   string `json:"valid_until"`
+		PricePerHour int    `json:"price_per_hour" binding:"min=0"`
+		Priority     int    `json:"priority"`
+	}
+
+	UpdatePricingRuleRequest struct {
+		PricingRuleID string  `json:"-"`
+		Name          *string `json:"name"`
+		DayOfWeek     *int    `json:"day_of_week"`
+		// ClearDayOfWeek makes the rule apply on every day again; a missing day_of_week
+		// leaves the current day unchanged.
+		ClearDayOfWeek bool    `json:"clear_day_of_week"`
+		StartTime      *string `json:"start_time"`
+		EndTime        *string `json:"end_time"`
+		ValidFrom      *string `json:"valid_from"`
+		ValidUntil     *string `json:"valid_until"`
+		PricePerHour   *int    `json:"price_per_hour"`
+		Priority       *int    `json:"priority"`
+	}
+
+	DeletePricingRuleRequest struct {
+		PricingRuleID string `json:"-"`
+	}
+
+	PricingRuleResponse struct {
+		PricingRuleID uuid.UUID `json:"pricing_rule_id"`
+		FieldID       uuid.UUID `json:"field_id"`
+		Name          string    `json:"name"`
+		DayOfWeek     *int      `json:"day_of_week"`
+		DayName       string    `json:"day_name,omitempty"`
+		StartTime     string    `json:"start_time"`
+		EndTime       string    `json:"end_time"`
+		ValidFrom     string    `json:"valid_from,omitempty"`
+		ValidUntil    string    `json:"valid_until,omitempty"`
+		PricePerHour  int       `json:"price_per_hour"`
+		Priority      int       `json:"priority"`
+	}
+)
@@ -31,6 +31,7 @@ type (
 		FieldAddress string                  `json:"field_address"`
 		FieldPrice   int                     `json:"field_price"`
 		FieldImage   string                  `json:"field_image"`
+		ImageURLs    *FieldImageURLs         `json:"image_urls"`
 		Category     CategoryCompactResponse `json:"category"`
 	}
 
@@ -52,4 +53,28 @@ type (
 	DeleteScheduleRequest struct {
 		ScheduleID string `json:"-"`
 	}
+
+	FieldAvailabilityRequest struct {
+		FieldID     string `form:"-"`
+		Date        string `form:"date"`
+		StartDate   string `form:"start_date"`
+		EndDate     string `form:"end_date"`
+		SlotMinutes int    `form:"slot_minutes"`
+	}
+
+	AvailabilitySlotResponse struct {
+		StartTime string  `json:"start_time"`
+		EndTime   string  `json:"end_time"`
+		Price     float64 `json:"price"`
+	}
+
+	FieldAvailabilityResponse struct {
+		Date      string                     `json:"date"`
+		DayOfWeek int                        `json:"day_of_week"`
+		DayName   string                     `json:"day_name"`
+		IsOpen    bool                       `json:"is_open"`
+		OpenTime  string                     `json:"open_time,omitempty"`
+		CloseTime string                     `json:"close_time,omitempty"`
+		Slots     []AvailabilitySlotResponse `json:"slots"`
+	}
 )
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"fieldreserve/model"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +14,9 @@ type (
 		Email   string    `json:"user_email"`
 		Address string    `json:"address"`
 		NoTelp  string    `json:"no_telp"`
+		Role    string    `json:"role,omitempty"`
+
+		EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 	}
 
 	CreateUserRequest struct {
@@ -24,6 +28,9 @@ type (
 	LoginUserRequest struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
+
+		UserAgent string `json:"-" form:"-"`
+		IPAddress string `json:"-" form:"-"`
 	}
 
 	LoginResponse struct {
@@ -31,6 +38,40 @@ type (
 		RefreshToken string `json:"refresh_token"`
 	}
 
+	RefreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+
+		UserAgent string `json:"-" form:"-"`
+		IPAddress string `json:"-" form:"-"`
+	}
+
+	ForgotPasswordRequest struct {
+		Email string `json:"email" binding:"required"`
+	}
+
+	ResetPasswordRequest struct {
+		Token    string `json:"token" binding:"required"`
+		Password string `json:"password" binding:"required"`
+	}
+
+	VerifyEmailRequest struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	SessionResponse struct {
+		SessionID  uuid.UUID `json:"session_id"`
+		Device     string    `json:"device"`
+		IPAddress  string    `json:"ip_address"`
+		LastSeenAt time.Time `json:"last_seen_at"`
+		CreatedAt  time.Time `json:"created_at"`
+		ExpiresAt  time.Time `json:"expires_at"`
+		Current    bool      `json:"current"`
+	}
+
+	RevokeSessionsResponse struct {
+		Revoked int `json:"revoked"`
+	}
+
 	UpdateUserRequest struct {
 		ID       string `json:"-"`
 		Name     string `json:"name,omitempty"`
@@ -40,6 +81,11 @@ type (
 		Password string `json:"password,omitempty"`
 	}
 
+	UpdateUserRoleRequest struct {
+		UserID string `json:"-"`
+		Role   string `json:"role" binding:"required,oneof=user staff admin"`
+	}
+
 	DeleteUserRequest struct {
 		UserID string `json:"-"`
 	}
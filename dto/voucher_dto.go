@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	CreateVoucherRequest struct {
+		Code          string   `json:"code" binding:"required,max=50"`
+		Description   string   `json:"description"`
+		DiscountType  string   `json:"discount_type" binding:"required,oneof=percent fixed"`
+		DiscountValue float64  `json:"discount_value" binding:"required,gt=0"`
+		MaxDiscount   float64  `json:"max_discount" binding:"min=0"`
+		MinSpend      float64  `json:"min_spend" binding:"min=0"`
+		ValidFrom     string   `json:"valid_from"`
+		ValidUntil    string   `json:"valid_until"`
+		UsageLimit    int      `json:"usage_limit" binding:"min=0"`
+		PerUserLimit  int      `json:"per_user_limit" binding:"min=0"`
+		IsActive      *bool    `json:"is_active"`
+		FieldIDs      []string `json:"field_ids"`
+		CategoryIDs   []string `json:"category_ids"`
+	}
+
+	UpdateVoucherRequest struct {
+		VoucherID     string    `json:"-"`
+		Description   *string   `json:"description"`
+		DiscountType  *string   `json:"discount_type" binding:"omitempty,oneof=percent fixed"`
+		DiscountValue *float64  `json:"discount_value"`
+		MaxDiscount   *float64  `json:"max_discount"`
+		MinSpend      *float64  `json:"min_spend"`
+		ValidFrom     *string   `json:"valid_from"`
+		ValidUntil    *string   `json:"valid_until"`
+		UsageLimit    *int      `json:"usage_limit"`
+		PerUserLimit  *int      `json:"per_user_limit"`
+		IsActive      *bool     `json:"is_active"`
+		FieldIDs      *[]string `json:"field_ids"`
+		CategoryIDs   *[]string `json:"category_ids"`
+	}
+
+	DeleteVoucherRequest struct {
+		VoucherID string `json:"-"`
+	}
+
+	VoucherResponse struct {
+		VoucherID     uuid.UUID   `json:"voucher_id"`
+		Code          string      `json:"code"`
+		Description   string      `json:"description"`
+		DiscountType  string      `json:"discount_type"`
+		DiscountValue float64     `json:"discount_value"`
+		MaxDiscount   float64     `json:"max_discount,omitempty"`
+		MinSpend      float64     `json:"min_spend,omitempty"`
+		ValidFrom     string      `json:"valid_from,omitempty"`
+		ValidUntil    string      `json:"valid_until,omitempty"`
+		UsageLimit    int         `json:"usage_limit"`
+		PerUserLimit  int         `json:"per_user_limit"`
+		IsActive      bool        `json:"is_active"`
+		FieldIDs      []uuid.UUID `json:"field_ids"`
+		CategoryIDs   []uuid.UUID `json:"category_ids"`
+		TimesRedeemed int64       `json:"times_redeemed"`
+	}
+
+	VoucherRedemptionResponse struct {
+		VoucherID      uuid.UUID `json:"voucher_id"`
+		Code           string    `json:"code"`
+		DiscountAmount float64   `json:"discount_amount"`
+		RedeemedAt     time.Time `json:"redeemed_at"`
+	}
+)
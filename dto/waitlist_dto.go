@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	JoinWaitlistRequest struct {
+		FieldID     string `json:"field_id" binding:"required"`
+		BookingDate string `json:"booking_date" binding:"required"`
+		StartTime   string `json:"start_time" binding:"required"`
+		EndTime     string `json:"end_time" binding:"required"`
+	}
+
+	WaitlistEntryResponse struct {
+		WaitlistID    uuid.UUID  `json:"waitlist_id"`
+		UserID        uuid.UUID  `json:"user_id"`
+		FieldID       uuid.UUID  `json:"field_id"`
+		BookingDate   time.Time  `json:"booking_date"`
+		StartTime     time.Time  `json:"start_time"`
+		EndTime       time.Time  `json:"end_time"`
+		Status        string     `json:"status"`
+		OfferedAt     *time.Time `json:"offered_at,omitempty"`
+		HoldExpiresAt *time.Time `json:"hold_expires_at,omitempty"`
+	}
+
+	WaitlistDemandRequest struct {
+		FieldID string `form:"field_id"`
+	}
+
+	WaitlistDemandRow struct {
+		FieldID      uuid.UUID
+		FieldName    string
+		BookingDate  time.Time
+		StartTime    time.Time
+		EndTime      time.Time
+		WaitingCount int64
+		OfferedCount int64
+	}
+
+	WaitlistSlotDemandResponse struct {
+		BookingDate  time.Time `json:"booking_date"`
+		StartTime    time.Time `json:"start_time"`
+		EndTime      time.Time `json:"end_time"`
+		WaitingCount int64     `json:"waiting_count"`
+		OfferedCount int64     `json:"offered_count"`
+	}
+
+	WaitlistDemandResponse struct {
+		FieldID      uuid.UUID                    `json:"field_id"`
+		FieldName    string                       `json:"field_name"`
+		WaitingCount int64                        `json:"waiting_count"`
+		OfferedCount int64                        `json:"offered_count"`
+		Slots        []WaitlistSlotDemandResponse `json:"slots"`
+	}
+)
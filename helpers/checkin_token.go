@@ -0,0 +1,57 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// checkInTokenPrefix versions the token format printed on invoices.
+const checkInTokenPrefix = "FRC1"
+
+var ErrInvalidCheckInToken = errors.New("invalid check-in token")
+
+// CheckInSecret is the key for booking check-in tokens. Like signed links it falls back
+// to the JWT secret when no dedicated secret is configured.
+func CheckInSecret() string {
+	if secret := os.Getenv("CHECKIN_TOKEN_SECRET"); secret != "" {
+		return secret
+	}
+	return os.Getenv("JWT_SECRET")
+}
+
+// SignCheckInToken returns the token encoded in the invoice QR code. It carries no expiry:
+// the booking's own check-in window decides when it can be used.
+func SignCheckInToken(bookingID uuid.UUID, secret string) string {
+	return checkInTokenPrefix + "." + bookingID.String() + "." + signCheckIn(bookingID, secret)
+}
+
+// ParseCheckInToken verifies a token from SignCheckInToken and returns its booking ID.
+func ParseCheckInToken(token, secret string) (uuid.UUID, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 || parts[0] != checkInTokenPrefix {
+		return uuid.Nil, ErrInvalidCheckInToken
+	}
+
+	bookingID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, ErrInvalidCheckInToken
+	}
+
+	if !hmac.Equal([]byte(parts[2]), []byte(signCheckIn(bookingID, secret))) {
+		return uuid.Nil, ErrInvalidCheckInToken
+	}
+
+	return bookingID, nil
+}
+
+func signCheckIn(bookingID uuid.UUID, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("check-in|" + bookingID.String()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
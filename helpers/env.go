@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+func GetEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("invalid integer for %s: %q, fallback to %d", key, value, fallback)
+		return fallback
+	}
+
+	return parsed
+}
+
+func GetEnvBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("invalid boolean for %s: %q, fallback to %t", key, value, fallback)
+		return fallback
+	}
+
+	return parsed
+}
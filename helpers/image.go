@@ -1,41 +1,335 @@
-// helpers/image.go
 package helpers
 
 import (
-	"fmt"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
 	"io"
 	"mime/multipart"
-	"os"
 	"path/filepath"
-	"time"
+	"strings"
 )
 
-func SaveImage(file *multipart.FileHeader, folder string, prefix string) (string, error) {
-	if _, err := os.Stat(folder); os.IsNotExist(err) {
-		if err := os.MkdirAll(folder, os.ModePerm); err != nil {
-			return "", err
-		}
+const (
+	// imageMaxDimension caps the stored original; larger photos are scaled down.
+	imageMaxDimension = 2560
+	// imageMaxPixels rejects images whose decoded size would exhaust memory.
+	imageMaxPixels   = 40_000_000
+	imageJPEGQuality = 85
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image format")
+	ErrImageTooLarge    = errors.New("image is too large")
+)
+
+type (
+	// ImageVariant is a resized copy that fits in a MaxSize x MaxSize box.
+	ImageVariant struct {
+		Name    string
+		MaxSize int
+	}
+
+	ProcessedImage struct {
+		Extension   string
+		ContentType string
+		Original    []byte
+		Variants    map[string][]byte
 	}
+)
+
+// FieldImageVariants are the sizes generated for every field photo.
+var FieldImageVariants = []ImageVariant{
+	{Name: "thumb", MaxSize: 320},
+	{Name: "medium", MaxSize: 800},
+	{Name: "large", MaxSize: 1600},
+}
 
-	ext := filepath.Ext(file.Filename)
-	filename := fmt.Sprintf("%s_%d%s", prefix, time.Now().UnixNano(), ext)
-	fullPath := filepath.Join(folder, filename)
+// MaxImageUploadBytes is the largest accepted upload, set by IMAGE_MAX_UPLOAD_MB.
+func MaxImageUploadBytes() int64 {
+	return int64(GetEnvInt("IMAGE_MAX_UPLOAD_MB", 5)) << 20
+}
+
+// ProcessImage checks an upload by its magic bytes rather than its extension, then
+// re-encodes it, which drops EXIF and any other metadata. JPEG orientation is applied to
+// the pixels first so photos keep facing the right way once the tag is gone.
+func ProcessImage(file *multipart.FileHeader, variants []ImageVariant) (ProcessedImage, error) {
+	maxBytes := MaxImageUploadBytes()
+	if file.Size > maxBytes {
+		return ProcessedImage{}, ErrImageTooLarge
+	}
 
 	src, err := file.Open()
 	if err != nil {
-		return "", err
+		return ProcessedImage{}, err
 	}
 	defer src.Close()
 
-	dst, err := os.Create(fullPath)
+	data, err := io.ReadAll(io.LimitReader(src, maxBytes+1))
+	if err != nil {
+		return ProcessedImage{}, err
+	}
+	if int64(len(data)) > maxBytes {
+		return ProcessedImage{}, ErrImageTooLarge
+	}
+
+	format := detectImageFormat(data)
+	if format == "" {
+		return ProcessedImage{}, ErrUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ProcessedImage{}, ErrUnsupportedImage
+	}
+	if config.Width*config.Height > imageMaxPixels {
+		return ProcessedImage{}, ErrImageTooLarge
+	}
+
+	var decoded image.Image
+	if format == "jpeg" {
+		decoded, err = jpeg.Decode(bytes.NewReader(data))
+	} else {
+		decoded, err = png.Decode(bytes.NewReader(data))
+	}
 	if err != nil {
-		return "", err
+		return ProcessedImage{}, ErrUnsupportedImage
+	}
+
+	img := toRGBA(decoded)
+	if format == "jpeg" {
+		img = orientImage(img, jpegOrientation(data))
+	}
+
+	result := ProcessedImage{
+		Variants: map[string][]byte{},
+	}
+	encode := encodePNG
+	if format == "jpeg" {
+		encode = encodeJPEG
+		result.Extension = ".jpg"
+		result.ContentType = "image/jpeg"
+	} else {
+		result.Extension = ".png"
+		result.ContentType = "image/png"
+	}
+
+	if result.Original, err = encode(resizeToFit(img, imageMaxDimension)); err != nil {
+		return ProcessedImage{}, err
+	}
+	for _, variant := range variants {
+		if result.Variants[variant.Name], err = encode(resizeToFit(img, variant.MaxSize)); err != nil {
+			return ProcessedImage{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// ImageVariantName is the file name of a variant, e.g. field_1.jpg -> field_1_thumb.jpg.
+func ImageVariantName(name, variant string) string {
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + "_" + variant + ext
+}
+
+// ImageVariantSource returns the original a variant file name was derived from.
+func ImageVariantSource(name string, variants []ImageVariant) (string, bool) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for _, variant := range variants {
+		if original, ok := strings.CutSuffix(base, "_"+variant.Name); ok && original != "" {
+			return original + ext, true
+		}
+	}
+	return "", false
+}
+
+func detectImageFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return "jpeg"
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return "png"
+	default:
+		return ""
+	}
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: imageJPEGQuality})
+	return buf.Bytes(), err
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	return buf.Bytes(), err
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// resizeToFit scales src down to fit in a max x max box by averaging the source pixels
+// under each target pixel. Images that already fit are returned unchanged.
+func resizeToFit(src *image.RGBA, max int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw <= max && sh <= max {
+		return src
+	}
+
+	dw, dh := max, sh*max/sw
+	if sh > sw {
+		dw, dh = sw*max/sh, max
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, (y+1)*sh/dh
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, (x+1)*sw/dw
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				i := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					b += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					i += 4
+					n++
+				}
+			}
+
+			j := y*dst.Stride + x*4
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(b / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// orientImage applies an EXIF orientation (1-8) so the pixels face up without the tag.
+func orientImage(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := sw, sh
+	if orientation >= 5 {
+		dw, dh = sh, sw
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = sw-1-x, y
+			case 3:
+				sx, sy = sw-1-x, sh-1-y
+			case 4:
+				sx, sy = x, sh-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, sh-1-x
+			case 7:
+				sx, sy = sw-1-y, sh-1-x
+			case 8:
+				sx, sy = sw-1-y, x
+			}
+
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:sy*src.Stride+sx*4+4])
+		}
 	}
-	defer dst.Close()
 
-	if _, err := io.Copy(dst, src); err != nil {
-		return "", err
+	return dst
+}
+
+// jpegOrientation reads the EXIF orientation tag of a JPEG, returning 1 when absent.
+func jpegOrientation(data []byte) int {
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[offset : offset+2]))
+	for n := 0; n < entries; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8 : entry+10]))
+		}
 	}
 
-	return filename, nil
+	return 1
 }
@@ -0,0 +1,147 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// QRIS tags from the EMVCo merchant-presented mode specification.
+const (
+	qrisTagPayloadFormat    = "00"
+	qrisTagInitiationMethod = "01"
+	qrisTagMerchantAccount  = "26"
+	qrisTagMerchantDomestic = "51"
+	qrisTagCategoryCode     = "52"
+	qrisTagCurrency         = "53"
+	qrisTagAmount           = "54"
+	qrisTagCountryCode      = "58"
+	qrisTagMerchantName     = "59"
+	qrisTagMerchantCity     = "60"
+	qrisTagPostalCode       = "61"
+	qrisTagAdditionalData   = "62"
+	qrisTagCRC              = "63"
+
+	qrisDynamic      = "12"
+	qrisCurrencyIDR  = "360"
+	qrisDomesticGUID = "ID.CO.QRIS.WWW"
+)
+
+var ErrQRISNotConfigured = errors.New("QRIS merchant is not configured")
+
+type QRISMerchant struct {
+	AcquirerGUID string
+	MerchantPAN  string
+	MerchantID   string
+	NMID         string
+	Criteria     string
+	CategoryCode string
+	Name         string
+	City         string
+	PostalCode   string
+}
+
+func QRISMerchantFromEnv() QRISMerchant {
+	merchant := QRISMerchant{
+		AcquirerGUID: os.Getenv("QRIS_ACQUIRER_GUID"),
+		MerchantPAN:  os.Getenv("QRIS_MERCHANT_PAN"),
+		MerchantID:   os.Getenv("QRIS_MERCHANT_ID"),
+		NMID:         os.Getenv("QRIS_NMID"),
+		Criteria:     os.Getenv("QRIS_MERCHANT_CRITERIA"),
+		CategoryCode: os.Getenv("QRIS_MCC"),
+		Name:         os.Getenv("QRIS_MERCHANT_NAME"),
+		City:         os.Getenv("QRIS_MERCHANT_CITY"),
+		PostalCode:   os.Getenv("QRIS_POSTAL_CODE"),
+	}
+
+	if merchant.Criteria == "" {
+		merchant.Criteria = "UMI"
+	}
+	if merchant.CategoryCode == "" {
+		// 7941: athletic fields and sports clubs.
+		merchant.CategoryCode = "7941"
+	}
+
+	return merchant
+}
+
+// BuildQRISPayload renders a dynamic QRIS string for a single payment. The reference is
+// carried as the bill number so the merchant can reconcile incoming transfers.
+func BuildQRISPayload(merchant QRISMerchant, amount float64, reference string) (string, error) {
+	if merchant.Name == "" || merchant.City == "" || (merchant.MerchantPAN == "" && merchant.NMID == "") {
+		return "", ErrQRISNotConfigured
+	}
+
+	rounded := math.Round(amount)
+	if rounded <= 0 {
+		return "", fmt.Errorf("invalid QRIS amount %.2f", amount)
+	}
+
+	var b strings.Builder
+	b.WriteString(qrisTLV(qrisTagPayloadFormat, "01"))
+	b.WriteString(qrisTLV(qrisTagInitiationMethod, qrisDynamic))
+
+	if merchant.MerchantPAN != "" {
+		b.WriteString(qrisTLV(qrisTagMerchantAccount,
+			qrisTLV("00", merchant.AcquirerGUID)+
+				qrisTLV("01", merchant.MerchantPAN)+
+				qrisTLV("02", merchant.MerchantID)+
+				qrisTLV("03", merchant.Criteria)))
+	}
+	if merchant.NMID != "" {
+		b.WriteString(qrisTLV(qrisTagMerchantDomestic,
+			qrisTLV("00", qrisDomesticGUID)+
+				qrisTLV("02", merchant.NMID)+
+				qrisTLV("03", merchant.Criteria)))
+	}
+
+	b.WriteString(qrisTLV(qrisTagCategoryCode, merchant.CategoryCode))
+	b.WriteString(qrisTLV(qrisTagCurrency, qrisCurrencyIDR))
+	b.WriteString(qrisTLV(qrisTagAmount, fmt.Sprintf("%.0f", rounded)))
+	b.WriteString(qrisTLV(qrisTagCountryCode, "ID"))
+	b.WriteString(qrisTLV(qrisTagMerchantName, qrisTruncate(merchant.Name, 25)))
+	b.WriteString(qrisTLV(qrisTagMerchantCity, qrisTruncate(merchant.City, 15)))
+	b.WriteString(qrisTLV(qrisTagPostalCode, merchant.PostalCode))
+	if reference != "" {
+		b.WriteString(qrisTLV(qrisTagAdditionalData, qrisTLV("01", qrisTruncate(reference, 25))))
+	}
+
+	// The checksum covers everything up to and including its own tag and length.
+	b.WriteString(qrisTagCRC + "04")
+	b.WriteString(fmt.Sprintf("%04X", crc16CCITT([]byte(b.String()))))
+
+	return b.String(), nil
+}
+
+// qrisTLV encodes one data object; empty values are left out entirely.
+func qrisTLV(tag, value string) string {
+	if value == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s%02d%s", tag, len(value), value)
+}
+
+// crc16CCITT computes CRC-16/CCITT-FALSE (polynomial 0x1021, initial value 0xFFFF).
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func qrisTruncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
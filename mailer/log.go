@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"context"
+	"fieldreserve/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogMailer writes every message, body included, to the application log. Never use it in
+// production: the body carries single-use links.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (lm *LogMailer) Send(ctx context.Context, msg Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+	}).Info(msg.Body)
+
+	return nil
+}
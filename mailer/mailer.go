@@ -0,0 +1,79 @@
+// Package mailer delivers transactional email such as password reset links. SMTP is used
+// in production; local setups write messages to a directory or to the log so the links
+// can be read without a mail server.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrInvalidMessage = errors.New("invalid mail message")
+
+type (
+	Mailer interface {
+		Send(ctx context.Context, msg Message) error
+	}
+
+	// Message is a plain-text email to a single recipient.
+	Message struct {
+		To      string
+		Subject string
+		Body    string
+	}
+)
+
+// NewFromEnv builds the mailer named by MAIL_DRIVER, defaulting to the log.
+func NewFromEnv() (Mailer, error) {
+	driver := os.Getenv("MAIL_DRIVER")
+	if driver == "" {
+		driver = "log"
+	}
+
+	switch driver {
+	case "log":
+		return NewLogMailer(), nil
+	case "file":
+		dir := os.Getenv("MAIL_FILE_DIR")
+		if dir == "" {
+			dir = "./mail"
+		}
+		return NewFileMailer(dir), nil
+	case "smtp":
+		return NewSMTPMailerFromEnv()
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", driver)
+	}
+}
+
+// validate rejects line breaks in header values, which would let a caller inject headers.
+func (m Message) validate() error {
+	if m.To == "" || strings.ContainsAny(m.To, "\r\n") || strings.ContainsAny(m.Subject, "\r\n") {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+// compose renders msg as an RFC 5322 plain-text message.
+func compose(from string, msg Message) []byte {
+	var buf bytes.Buffer
+	if from != "" {
+		fmt.Fprintf(&buf, "From: %s\r\n", from)
+	}
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,77 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+)
+
+// SMTPMailer sends through an SMTP server, upgrading to TLS with STARTTLS when offered.
+type SMTPMailer struct {
+	addr     string
+	host     string
+	from     string
+	username string
+	password string
+}
+
+func NewSMTPMailer(host string, port int, from, username, password string) *SMTPMailer {
+	return &SMTPMailer{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		host:     host,
+		from:     from,
+		username: username,
+		password: password,
+	}
+}
+
+func NewSMTPMailerFromEnv() (*SMTPMailer, error) {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil, errors.New("SMTP_HOST is required for the smtp mail driver")
+	}
+
+	port := 587
+	if value := os.Getenv("SMTP_PORT"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT %q", value)
+		}
+		port = parsed
+	}
+
+	username := os.Getenv("SMTP_AUTH_EMAIL")
+	from := os.Getenv("SMTP_SENDER_NAME")
+	if from == "" {
+		from = username
+	}
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("invalid SMTP_SENDER_NAME %q: %w", from, err)
+	}
+
+	return NewSMTPMailer(host, port, from, username, os.Getenv("SMTP_AUTH_PASSWORD")), nil
+}
+
+// Send ignores ctx: net/smtp has no context support, so the server's own timeouts apply.
+func (sm *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	sender, err := mail.ParseAddress(sm.from)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if sm.username != "" {
+		auth = smtp.PlainAuth("", sm.username, sm.password, sm.host)
+	}
+
+	return smtp.SendMail(sm.addr, auth, sender.Address, []string{msg.To}, compose(sm.from, msg))
+}
@@ -1,15 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fieldreserve/cmd"
 	"fieldreserve/config/database"
 	"fieldreserve/controller"
+	"fieldreserve/mailer"
 	"fieldreserve/middleware"
+	"fieldreserve/payment"
 	"fieldreserve/repository"
+	"fieldreserve/revocation"
 	"fieldreserve/routes"
 	"fieldreserve/service"
+	"fieldreserve/storage"
 	"fieldreserve/utils" // tambahkan ini
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -36,12 +46,36 @@ func main() {
 		return
 	}
 
+	// ==== Payment provider ====
+	paymentProvider, err := payment.NewProviderFromEnv()
+	if err != nil {
+		utils.Log.WithError(err).Fatal("Failed to set up payment provider")
+	}
+	utils.Log.WithField("provider", paymentProvider.Name()).Info("Payment provider initialized")
+
+	// ==== Storage ====
+	store, err := storage.NewFromEnv()
+	if err != nil {
+		utils.Log.WithError(err).Fatal("Failed to set up storage")
+	}
+
+	revocations, err := revocation.NewFromEnv(db)
+	if err != nil {
+		utils.Log.WithError(err).Fatal("Failed to set up token revocation store")
+	}
+
+	mail, err := mailer.NewFromEnv()
+	if err != nil {
+		utils.Log.WithError(err).Fatal("Failed to set up mailer")
+	}
+
 	// ==== Inisialisasi ====
 	var (
-		jwtService = service.NewJWTService()
+		jwtService = service.NewJWTService(revocations)
 
 		userRepo       = repository.NewUserRepository(db)
-		userService    = service.NewUserService(userRepo, jwtService)
+		tokenRepo      = repository.NewTokenRepository(db)
+		userService    = service.NewUserService(userRepo, tokenRepo, jwtService, mail)
 		userController = controller.NewUserController(userService)
 
 		categoryRepo       = repository.NewCategoryRepository(db)
@@ -49,27 +83,61 @@ func main() {
 		categoryController = controller.NewCategoryController(categoryService)
 
 		fieldRepo       = repository.NewFieldRepository(db)
-		fieldService    = service.NewFieldService(fieldRepo)
+		fieldService    = service.NewFieldService(fieldRepo, store)
 		fieldController = controller.NewFieldController(fieldService)
 
-		scheduleRepo     = repository.NewScheduleRepository(db)
-		scheduleService  = service.NewScheduleService(scheduleRepo, fieldRepo)
+		pricingRuleRepo       = repository.NewPricingRuleRepository(db)
+		pricingRuleService    = service.NewPricingRuleService(pricingRuleRepo, fieldRepo)
+		pricingRuleController = controller.NewPricingRuleController(pricingRuleService)
+		pricingService        = service.NewPricingService(pricingRuleRepo)
+
+		bookingRepo = repository.NewBookingRepository(db)
+
+		notificationService = service.NewMailNotificationService(userRepo, mail)
+
+		scheduleRepo       = repository.NewScheduleRepository(db)
+		scheduleService    = service.NewScheduleService(scheduleRepo, fieldRepo, bookingRepo, pricingService)
 		scheduleController = controller.NewScheduleController(scheduleService)
 
-		bookingRepo       = repository.NewBookingRepository(db)
-		bookingService    = service.NewBookingService(bookingRepo, jwtService, scheduleRepo, fieldRepo)
+		voucherRepo       = repository.NewVoucherRepository(db)
+		voucherService    = service.NewVoucherService(voucherRepo, fieldRepo, categoryRepo)
+		voucherController = controller.NewVoucherController(voucherService)
+
+		waitlistRepo       = repository.NewWaitlistRepository(db)
+		waitlistService    = service.NewWaitlistService(waitlistRepo, bookingRepo, fieldRepo, scheduleRepo, jwtService, notificationService)
+		waitlistController = controller.NewWaitlistController(waitlistService)
+
+		bookingService    = service.NewBookingService(bookingRepo, jwtService, scheduleRepo, fieldRepo, userRepo, pricingService, waitlistService, voucherService, notificationService, store)
 		bookingController = controller.NewBookingController(bookingService)
+
+		bookingSeriesRepo       = repository.NewBookingSeriesRepository(db)
+		bookingSeriesService    = service.NewBookingSeriesService(bookingSeriesRepo, bookingRepo, jwtService, scheduleRepo, fieldRepo, userRepo, pricingService, waitlistService)
+		bookingSeriesController = controller.NewBookingSeriesController(bookingSeriesService)
+
+		fileService    = service.NewFileService(store)
+		fileController = controller.NewFileController(fileService)
+
+		paymentRepo       = repository.NewPaymentRepository(db)
+		paymentService    = service.NewPaymentService(paymentRepo, bookingRepo, paymentProvider, jwtService, waitlistService)
+		paymentController = controller.NewPaymentController(paymentService)
+
+		bookingExpiryWorker = service.NewBookingExpiryWorker(bookingRepo, waitlistService)
 	)
 
+	// ==== Background Worker ====
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go bookingExpiryWorker.Start(ctx)
+
 	// ==== Router ====
 	server := gin.Default()
 	server.Use(middleware.CORSMiddleware())
 
-	routes.PublicRoutes(server, userController)
-	routes.UserRoutes(server, userController, categoryController, fieldController, scheduleController, bookingController, jwtService)
-	routes.AdminRoutes(server, userController, categoryController, fieldController, scheduleController, bookingController, jwtService)
-
-	server.Static("/assets", "./assets")
+	routes.PublicRoutes(server, userController, fileController, paymentController)
+	routes.UserRoutes(server, userController, categoryController, fieldController, scheduleController, bookingController, bookingSeriesController, waitlistController, paymentController, jwtService)
+	routes.AdminRoutes(server, userController, categoryController, fieldController, scheduleController, bookingController, pricingRuleController, waitlistController, voucherController, paymentController, jwtService)
+	routes.StaffRoutes(server, bookingController, jwtService)
 
 	// ==== Port ====
 	port := os.Getenv("PORT")
@@ -86,7 +154,25 @@ func main() {
 
 	utils.Log.WithField("address", serve).Info("Starting server...")
 
-	if err := server.Run(serve); err != nil {
-		utils.Log.WithError(err).Fatal("Error running server")
+	srv := &http.Server{
+		Addr:    serve,
+		Handler: server,
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			utils.Log.WithError(err).Fatal("Error running server")
+		}
+	}()
+
+	// ==== Graceful Shutdown ====
+	<-ctx.Done()
+	utils.Log.Info("Shutting down server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		utils.Log.WithError(err).Error("Error shutting down server")
 	}
 }
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fieldreserve/constants"
 	"fieldreserve/service"
 	"fieldreserve/utils"
@@ -30,30 +31,23 @@ func Authentication(jwtService service.InterfaceJWTService) gin.HandlerFunc {
 
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 
-		token, err := jwtService.ValidateToken(tokenStr)
-		if err != nil || !token.Valid {
-			utils.Log.Warnf("Token invalid: %v", err)
-			res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_PROSES_REQUEST, constants.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
-			return
-		}
-
-		userID, err := jwtService.GetUserIDByToken(tokenStr)
-		if err != nil {
-			utils.Log.Errorf("failed extrack userID from token: %v", err)
+		// Refresh tokens are rejected here; they are only accepted by /api/users/refresh.
+		claims, err := jwtService.ValidateAccessToken(ctx.Request.Context(), tokenStr)
+		if errors.Is(err, constants.ErrCheckTokenRevocation) {
+			utils.Log.Errorf("failed check token revocation: %v", err)
 			res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
 			return
 		}
-
-		role, err := jwtService.GetRoleByToken(tokenStr)
 		if err != nil {
-			utils.Log.Errorf("failed extrack role from token: %v", err)
-			res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			utils.Log.Warnf("Token invalid: %v", err)
+			res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_PROSES_REQUEST, constants.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
 
+		userID, role := claims.UserID, claims.Role
+
 		utils.Log.Infof("Autentikasi successfull - UserID: %s, Role: %s", userID, role)
 
 		ctx.Set("Authorization", tokenStr)
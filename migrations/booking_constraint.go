@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"fieldreserve/constants"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MigrateBookingOverlapConstraint installs an exclusion constraint that stops two
+// active bookings on the same field from covering overlapping time ranges. It is only
+// created when missing; changes to its definition belong in a dedicated migration.
+func MigrateBookingOverlapConstraint(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS btree_gist").Error; err != nil {
+		return fmt.Errorf("failed to enable btree_gist extension: %w", err)
+	}
+
+	_, exists, err := bookingOverlapConstraintDef(db)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	if err := db.Exec(bookingOverlapConstraintSQL()).Error; err != nil {
+		return fmt.Errorf("failed to add bookings_no_overlap constraint: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateBookingOverlapInactiveStatuses upgrades constraints created when only cancelled
+// bookings were excluded, so rejected and refunded bookings stop holding their slot.
+// Constraints that already exclude every inactive status are left alone.
+func MigrateBookingOverlapInactiveStatuses(db *gorm.DB) error {
+	def, exists, err := bookingOverlapConstraintDef(db)
+	if err != nil || !exists {
+		return err
+	}
+
+	outdated := false
+	for _, status := range constants.InactiveBookingStatuses {
+		if !strings.Contains(def, "'"+status+"'") {
+			outdated = true
+			break
+		}
+	}
+	if !outdated {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("ALTER TABLE bookings DROP CONSTRAINT bookings_no_overlap").Error; err != nil {
+			return fmt.Errorf("failed to drop bookings_no_overlap constraint: %w", err)
+		}
+		if err := tx.Exec(bookingOverlapConstraintSQL()).Error; err != nil {
+			return fmt.Errorf("failed to re-create bookings_no_overlap constraint: %w", err)
+		}
+		return nil
+	})
+}
+
+func bookingOverlapConstraintDef(db *gorm.DB) (string, bool, error) {
+	var defs []string
+	query := `SELECT pg_get_constraintdef(oid) FROM pg_constraint
+		WHERE conname = 'bookings_no_overlap' AND conrelid = 'bookings'::regclass`
+	if err := db.Raw(query).Scan(&defs).Error; err != nil {
+		return "", false, fmt.Errorf("failed to look up bookings_no_overlap constraint: %w", err)
+	}
+	if len(defs) == 0 {
+		return "", false, nil
+	}
+
+	return defs[0], true, nil
+}
+
+func bookingOverlapConstraintSQL() string {
+	inactive := make([]string, 0, len(constants.InactiveBookingStatuses))
+	for _, status := range constants.InactiveBookingStatuses {
+		inactive = append(inactive, "'"+status+"'")
+	}
+
+	return fmt.Sprintf(`ALTER TABLE bookings ADD CONSTRAINT bookings_no_overlap
+		EXCLUDE USING gist (field_id WITH =, tstzrange(start_time, end_time, '[)') WITH &&)
+		WHERE (status NOT IN (%s) AND deleted_at IS NULL)`, strings.Join(inactive, ", "))
+}
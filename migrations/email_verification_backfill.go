@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// BackfillEmailVerification treats accounts created before email verification existed as
+// verified, so turning on REQUIRE_EMAIL_VERIFICATION does not lock existing customers
+// out. Migrate only runs it when the column is first added.
+func BackfillEmailVerification(db *gorm.DB) error {
+	query := `UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL`
+	if err := db.Exec(query).Error; err != nil {
+		return fmt.Errorf("failed to backfill email verification: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// BackfillFieldImages adds the single photo of fields created before galleries existed
+// as their cover image. Fields that already have a gallery are left alone.
+func BackfillFieldImages(db *gorm.DB) error {
+	query := `INSERT INTO field_images (image_id, field_id, file_name, caption, sort_order, is_cover, created_at, updated_at)
+		SELECT gen_random_uuid(), f.field_id, f.field_image, '', 0, true, NOW(), NOW()
+		FROM fields f
+		WHERE f.field_image <> '' AND f.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM field_images fi WHERE fi.field_id = f.field_id AND fi.deleted_at IS NULL)`
+	if err := db.Exec(query).Error; err != nil {
+		return fmt.Errorf("failed to backfill field images: %w", err)
+	}
+
+	return nil
+}
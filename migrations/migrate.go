@@ -9,9 +9,15 @@ import (
 func Migrate(db *gorm.DB) error {
 	db = db.Debug()
 
+	hadEmailVerification := db.Migrator().HasColumn(&model.User{}, "EmailVerifiedAt")
 	if err := db.AutoMigrate(&model.User{}); err != nil {
 		return err
 	}
+	if !hadEmailVerification {
+		if err := BackfillEmailVerification(db); err != nil {
+			return err
+		}
+	}
 	if err := db.AutoMigrate(&model.Category{}); err != nil {
 		return err
 	}
@@ -21,9 +27,60 @@ func Migrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(&model.Schedule{}); err != nil {
 		return err
 	}
+	if err := db.AutoMigrate(&model.PricingRule{}); err != nil {
+		return err
+	}
 	if err := db.AutoMigrate(&model.Booking{}); err != nil {
 		return err
 	}
+	if err := MigrateBookingOverlapConstraint(db); err != nil {
+		return err
+	}
+	if err := MigrateBookingOverlapInactiveStatuses(db); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.BookingPriceItem{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.BookingSeries{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.BookingStatusHistory{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.WaitlistEntry{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.Voucher{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.VoucherRedemption{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.Payment{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.PaymentProof{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.FieldImage{}); err != nil {
+		return err
+	}
+	if err := BackfillFieldImages(db); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.TokenFamily{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.RefreshToken{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.RevokedToken{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.UserToken{}); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -12,7 +12,23 @@ func Rollback(db *gorm.DB) error {
 		&model.Category{},
 		&model.Field{},
 		&model.Schedule{},
+		&model.PricingRule{},
 		&model.Booking{},
+		&model.BookingPriceItem{},
+		&model.BookingSeries{},
+		&model.BookingStatusHistory{},
+		&model.WaitlistEntry{},
+		&model.VoucherRedemption{},
+		"voucher_fields",
+		"voucher_categories",
+		&model.Voucher{},
+		&model.Payment{},
+		&model.PaymentProof{},
+		&model.FieldImage{},
+		&model.TokenFamily{},
+		&model.RefreshToken{},
+		&model.RevokedToken{},
+		&model.UserToken{},
 	}
 
 	for _, table := range tables {
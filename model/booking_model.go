@@ -7,23 +7,34 @@ import (
 )
 
 type Booking struct {
-	BookingID     uuid.UUID `gorm:"type:uuid;primaryKey;column:booking_id"`
-	UserID        uuid.UUID `gorm:"type:uuid;not null"`
-	FieldID       uuid.UUID `gorm:"type:uuid;not null"`
-	PaymentMethod string    `json:"payment_method"`
-	BookingDate   time.Time `json:"booking_date"`
-	StartTime     time.Time `json:"start_time"`
-	EndTime       time.Time `json:"end_time"`
-	TotalPayment  float64   `json:"total_payment"`
-	ProofPayment  string    `json:"proof_payment"`
-	Status        string    `json:"status"`
+	BookingID     uuid.UUID  `gorm:"type:uuid;primaryKey;column:booking_id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null"`
+	FieldID       uuid.UUID  `gorm:"type:uuid;not null"`
+	SeriesID      *uuid.UUID `gorm:"type:uuid;index"`
+	PaymentMethod string     `json:"payment_method"`
+	BookingDate   time.Time  `json:"booking_date"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       time.Time  `json:"end_time"`
+	TotalPayment  float64    `json:"total_payment"`
+	ProofPayment  string     `json:"proof_payment"`
+	Status        string     `json:"status"`
 
-	PaymentUploadedAt *time.Time `json:"payment_uploaded_at"`
-	PaymentVerifiedAt *time.Time `json:"payment_verified_at"`
-	CancelledAt       *time.Time `json:"cancelled_at"`
+	PaymentDueAt          *time.Time `json:"payment_due_at" gorm:"index"`
+	PaymentUploadedAt     *time.Time `json:"payment_uploaded_at"`
+	PaymentVerifiedAt     *time.Time `json:"payment_verified_at"`
+	VerificationFlaggedAt *time.Time `json:"verification_flagged_at"`
+	CancelledAt           *time.Time `json:"cancelled_at"`
+	CancelReason          string     `json:"cancel_reason"`
+	RefundAmount          float64    `json:"refund_amount"`
+	PaymentAdjustment     float64    `json:"payment_adjustment"`
+	RescheduleCount       int        `json:"reschedule_count"`
+	CheckedInAt           *time.Time `json:"checked_in_at"`
+	CheckedInBy           *uuid.UUID `gorm:"type:uuid" json:"checked_in_by"`
 
-	User  User  `gorm:"foreignKey:UserID;references:UserID"`
-	Field Field `gorm:"foreignKey:FieldID;references:FieldID"`
+	User       User               `gorm:"foreignKey:UserID;references:UserID"`
+	Field      Field              `gorm:"foreignKey:FieldID;references:FieldID"`
+	PriceItems []BookingPriceItem `gorm:"foreignKey:BookingID;references:BookingID"`
+	Redemption *VoucherRedemption `gorm:"foreignKey:BookingID;references:BookingID"`
 
 	TimeStamp
 }
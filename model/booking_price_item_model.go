@@ -0,0 +1,16 @@
+package model
+
+import "github.com/google/uuid"
+
+type BookingPriceItem struct {
+	PriceItemID uuid.UUID `gorm:"type:uuid;primaryKey;column:price_item_id"`
+	BookingID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	ItemType    string    `json:"item_type"`
+	Label       string    `json:"label"`
+	Quantity    float64   `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	Amount      float64   `json:"amount"`
+	SortOrder   int       `json:"sort_order"`
+
+	TimeStamp
+}
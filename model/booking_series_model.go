@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingSeries struct {
+	SeriesID        uuid.UUID  `gorm:"type:uuid;primaryKey;column:series_id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index"`
+	FieldID         uuid.UUID  `gorm:"type:uuid;not null"`
+	PaymentMethod   string     `json:"payment_method"`
+	IntervalWeeks   int        `json:"interval_weeks"`
+	DayOfWeek       int        `json:"day_of_week"`
+	StartTime       string     `json:"start_time" gorm:"type:varchar(5);not null"`
+	EndTime         string     `json:"end_time" gorm:"type:varchar(5);not null"`
+	StartDate       time.Time  `json:"start_date" gorm:"type:date"`
+	UntilDate       *time.Time `json:"until_date" gorm:"type:date"`
+	OccurrenceCount int        `json:"occurrence_count"`
+	Status          string     `json:"status"`
+	CancelledAt     *time.Time `json:"cancelled_at"`
+
+	User     User      `gorm:"foreignKey:UserID;references:UserID"`
+	Field    Field     `gorm:"foreignKey:FieldID;references:FieldID"`
+	Bookings []Booking `gorm:"foreignKey:SeriesID;references:SeriesID"`
+
+	TimeStamp
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingStatusHistory struct {
+	HistoryID  uuid.UUID  `gorm:"type:uuid;primaryKey;column:history_id"`
+	BookingID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	ChangedBy  *uuid.UUID `gorm:"type:uuid" json:"changed_by"`
+	ActorRole  string     `json:"actor_role"`
+	Reason     string     `json:"reason"`
+	ChangedAt  time.Time  `json:"changed_at"`
+
+	TimeStamp
+}
+
+func (BookingStatusHistory) TableName() string {
+	return "booking_status_history"
+}
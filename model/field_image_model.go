@@ -0,0 +1,16 @@
+package model
+
+import "github.com/google/uuid"
+
+// FieldImage is one photo in a field's gallery. Field.FieldImage mirrors the file name of
+// the cover so listings don't need to load the gallery.
+type FieldImage struct {
+	ImageID   uuid.UUID `gorm:"type:uuid;primaryKey;column:image_id"`
+	FieldID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	FileName  string    `json:"file_name"`
+	Caption   string    `json:"caption"`
+	SortOrder int       `gorm:"not null;default:0" json:"sort_order"`
+	IsCover   bool      `gorm:"not null;default:false" json:"is_cover"`
+
+	TimeStamp
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Payment struct {
+	PaymentID    uuid.UUID  `gorm:"type:uuid;primaryKey;column:payment_id"`
+	BookingID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Provider     string     `json:"provider" gorm:"type:varchar(30);not null"`
+	ProviderRef  string     `json:"provider_ref" gorm:"not null;uniqueIndex"`
+	Amount       float64    `json:"amount"`
+	Status       string     `json:"status" gorm:"type:varchar(20);not null"`
+	PaymentURL   string     `json:"payment_url"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	PaidAt       *time.Time `json:"paid_at"`
+	RefundAmount float64    `json:"refund_amount"`
+	RefundedAt   *time.Time `json:"refunded_at"`
+
+	Booking Booking `gorm:"foreignKey:BookingID;references:BookingID"`
+
+	TimeStamp
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentProof keeps every proof a customer uploads for a booking; Booking.ProofPayment
+// only points at the latest one.
+type PaymentProof struct {
+	ProofID    uuid.UUID `gorm:"type:uuid;primaryKey;column:proof_id"`
+	BookingID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	UploadedBy uuid.UUID `gorm:"type:uuid;not null"`
+	FileName   string    `json:"file_name"`
+	UploadedAt time.Time `json:"uploaded_at"`
+
+	RejectedAt   *time.Time `json:"rejected_at"`
+	RejectedBy   *uuid.UUID `gorm:"type:uuid" json:"rejected_by"`
+	RejectReason string     `json:"reject_reason"`
+
+	TimeStamp
+}
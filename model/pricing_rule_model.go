@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PricingRule struct {
+	PricingRuleID uuid.UUID  `gorm:"type:uuid;primaryKey;column:pricing_rule_id"`
+	FieldID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name          string     `json:"name"`
+	DayOfWeek     *int       `json:"day_of_week"`
+	StartTime     string     `json:"start_time" gorm:"type:varchar(5);not null"`
+	EndTime       string     `json:"end_time" gorm:"type:varchar(5);not null"`
+	ValidFrom     *time.Time `json:"valid_from" gorm:"type:date"`
+	ValidUntil    *time.Time `json:"valid_until" gorm:"type:date"`
+	PricePerHour  int        `json:"price_per_hour"`
+	Priority      int        `json:"priority"`
+
+	Field Field `gorm:"foreignKey:FieldID;references:FieldID"`
+
+	TimeStamp
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenFamily groups every refresh token descended from one login, which makes it the
+// user's session on one device. Presenting a refresh token that was already rotated
+// revokes the whole family. Tokens carry the family ID as their session ID.
+type TokenFamily struct {
+	FamilyID     uuid.UUID  `gorm:"type:uuid;primaryKey;column:family_id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	UserAgent    string     `json:"user_agent"`
+	IPAddress    string     `json:"ip_address"`
+	LastSeenAt   time.Time  `json:"last_seen_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	RevokeReason string     `json:"revoke_reason"`
+
+	TimeStamp
+}
+
+// RefreshToken records one issued refresh token by its jti. UsedAt is set when it is
+// exchanged for a new pair, and ReplacedBy points at the token issued in its place.
+type RefreshToken struct {
+	TokenID    uuid.UUID  `gorm:"type:uuid;primaryKey;column:token_id"`
+	FamilyID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	UsedAt     *time.Time `json:"used_at"`
+	ReplacedBy *uuid.UUID `gorm:"type:uuid" json:"replaced_by"`
+
+	TimeStamp
+}
+
+// RevokedToken is a token or session ID that must be rejected until ExpiresAt, after
+// which every token carrying it has expired on its own.
+type RevokedToken struct {
+	TokenID   uuid.UUID `gorm:"type:uuid;primaryKey;column:token_id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
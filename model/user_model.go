@@ -2,6 +2,7 @@ package model
 
 import (
 	"fieldreserve/helpers"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -16,6 +17,8 @@ type User struct {
 	Address  string    `json:"address"`
 	Role     string    `json:"role"`
 
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+
 	TimeStamp
 }
 
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserToken is a single-use token mailed to a user, such as a password reset link. Only
+// the SHA-256 hash of the token is stored.
+type UserToken struct {
+	TokenID   uuid.UUID  `gorm:"type:uuid;primaryKey;column:token_id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Purpose   string     `gorm:"not null;index" json:"purpose"`
+	TokenHash string     `gorm:"not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+
+	TimeStamp
+}
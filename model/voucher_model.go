@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Voucher struct {
+	VoucherID     uuid.UUID  `gorm:"type:uuid;primaryKey;column:voucher_id"`
+	Code          string     `json:"code" gorm:"type:varchar(50);not null;uniqueIndex"`
+	Description   string     `json:"description"`
+	DiscountType  string     `json:"discount_type" gorm:"type:varchar(10);not null"`
+	DiscountValue float64    `json:"discount_value"`
+	MaxDiscount   float64    `json:"max_discount"`
+	MinSpend      float64    `json:"min_spend"`
+	ValidFrom     *time.Time `json:"valid_from" gorm:"type:date"`
+	ValidUntil    *time.Time `json:"valid_until" gorm:"type:date"`
+	UsageLimit    int        `json:"usage_limit"`
+	PerUserLimit  int        `json:"per_user_limit"`
+	IsActive      bool       `json:"is_active"`
+
+	// A voucher without fields or categories applies to every field.
+	Fields     []Field    `gorm:"many2many:voucher_fields;joinForeignKey:VoucherID;joinReferences:FieldID"`
+	Categories []Category `gorm:"many2many:voucher_categories;joinForeignKey:VoucherID;joinReferences:CategoryID"`
+
+	TimeStamp
+}
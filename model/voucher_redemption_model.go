@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VoucherRedemption struct {
+	RedemptionID   uuid.UUID `gorm:"type:uuid;primaryKey;column:redemption_id"`
+	VoucherID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	BookingID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	Code           string    `json:"code"`
+	DiscountAmount float64   `json:"discount_amount"`
+	RedeemedAt     time.Time `json:"redeemed_at"`
+
+	Voucher Voucher `gorm:"foreignKey:VoucherID;references:VoucherID"`
+
+	TimeStamp
+}
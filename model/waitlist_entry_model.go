@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistEntry struct {
+	WaitlistID    uuid.UUID  `gorm:"type:uuid;primaryKey;column:waitlist_id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index"`
+	FieldID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	BookingDate   time.Time  `json:"booking_date"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       time.Time  `json:"end_time"`
+	Status        string     `json:"status"`
+	OfferedAt     *time.Time `json:"offered_at"`
+	HoldExpiresAt *time.Time `json:"hold_expires_at"`
+
+	User  User  `gorm:"foreignKey:UserID;references:UserID"`
+	Field Field `gorm:"foreignKey:FieldID;references:FieldID"`
+
+	TimeStamp
+}
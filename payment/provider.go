@@ -0,0 +1,90 @@
+// Package payment defines the contract between the booking flow and payment gateways.
+// Each gateway lives behind PaymentProvider so it can be swapped through configuration.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/constants"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusPaid     = "paid"
+	StatusFailed   = "failed"
+	StatusExpired  = "expired"
+	StatusRefunded = "refunded"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrChargeNotFound   = errors.New("charge not found")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+	// ErrMissingWebhookSecret stops startup rather than verifying webhooks with a guessable key.
+	ErrMissingWebhookSecret = errors.New("PAYMENT_WEBHOOK_SECRET is required")
+)
+
+type (
+	PaymentProvider interface {
+		// Name identifies the provider in the payments table and in webhook routes.
+		Name() string
+		CreateCharge(ctx context.Context, req ChargeRequest) (Charge, error)
+		GetChargeStatus(ctx context.Context, providerRef string) (string, error)
+		Refund(ctx context.Context, providerRef string, amount float64) (Refund, error)
+		// VerifyWebhook checks the signature of a callback body and decodes it.
+		VerifyWebhook(payload []byte, signature string) (WebhookEvent, error)
+	}
+
+	ChargeRequest struct {
+		OrderID       string
+		Amount        float64
+		Description   string
+		CustomerName  string
+		CustomerEmail string
+		ExpiresAt     *time.Time
+	}
+
+	Charge struct {
+		ProviderRef string
+		Status      string
+		PaymentURL  string
+		ExpiresAt   *time.Time
+	}
+
+	Refund struct {
+		ProviderRef string
+		Amount      float64
+		RefundedAt  time.Time
+	}
+
+	WebhookEvent struct {
+		ProviderRef string    `json:"provider_ref"`
+		OrderID     string    `json:"order_id"`
+		Status      string    `json:"status"`
+		Amount      float64   `json:"amount"`
+		OccurredAt  time.Time `json:"occurred_at"`
+	}
+)
+
+// NewProviderFromEnv builds the provider named by PAYMENT_PROVIDER, defaulting to the
+// mock provider so the whole flow works offline.
+func NewProviderFromEnv() (PaymentProvider, error) {
+	name := os.Getenv("PAYMENT_PROVIDER")
+	if name == "" {
+		name = MockProviderName
+	}
+
+	switch name {
+	case MockProviderName:
+		// The mock confirms payments without moving money, so it must never serve real customers.
+		if os.Getenv("APP_ENV") == constants.ENUM_RUN_PRODUCTION {
+			return nil, errors.New("the mock payment provider cannot be used in production")
+		}
+		return NewMockProvider(os.Getenv("PAYMENT_WEBHOOK_SECRET"), os.Getenv("PAYMENT_MOCK_BASE_URL"))
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fieldreserve/constants"
 	"fieldreserve/dto"
 	"fieldreserve/model"
 	"math"
@@ -10,18 +11,34 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	IBookingRepository interface {
+		WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+		LockFieldForBooking(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID) error
 		CreateBooking(ctx context.Context, tx *gorm.DB, booking model.Booking) error
 		GetAllBooking(ctx context.Context, tx *gorm.DB, req dto.BookingPaginationRequest) (dto.BookingPaginationRepositoryResponse, error)
 		GetBookingByID(ctx context.Context, tx *gorm.DB, bookingID string) (model.Booking, bool, error)
 		UpdateBooking(ctx context.Context, tx *gorm.DB, booking model.Booking) error
+		RescheduleBooking(ctx context.Context, tx *gorm.DB, booking model.Booking) error
+		ReplaceBookingPriceItems(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, items []model.BookingPriceItem) error
 		DeleteBooking(ctx context.Context, tx *gorm.DB, bookingID string) error
-		CheckBookingOverlap(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, bookingDate time.Time, startTime, endTime time.Time) (bool, error)
+		CheckBookingOverlap(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, bookingDate time.Time, startTime, endTime time.Time, excludeBookingID uuid.UUID) (bool, error)
+		GetActiveBookingsByFieldAndDateRange(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, startDate, endDate time.Time) ([]model.Booking, error)
 		GetWaitingVerificationBookings(ctx context.Context, tx *gorm.DB) ([]model.Booking, error)
+		GetExpiredPendingBookings(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]model.Booking, error)
+		FlagBookingForVerification(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, flaggedAt time.Time) (bool, error)
+		MarkBookingCheckedIn(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, checkedInAt time.Time, checkedInBy uuid.UUID) (bool, error)
+		GetMissedCheckInBookings(ctx context.Context, tx *gorm.DB, startedBefore, startedAfter time.Time, limit int) ([]model.Booking, error)
 		UpdateBookingStatus(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, newStatus string) error
+		CreateBookingStatusHistory(ctx context.Context, tx *gorm.DB, history model.BookingStatusHistory) error
+		GetBookingStatusHistory(ctx context.Context, tx *gorm.DB, bookingID string) ([]model.BookingStatusHistory, error)
+		CreatePaymentProof(ctx context.Context, tx *gorm.DB, proof model.PaymentProof) error
+		GetPaymentProofs(ctx context.Context, tx *gorm.DB, bookingID string) ([]model.PaymentProof, error)
+		RejectPaymentProof(ctx context.Context, tx *gorm.DB, proof model.PaymentProof) error
+		ClearVerificationFlag(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) error
 	}
 
 	BookingRepository struct {
@@ -35,6 +52,20 @@ func NewBookingRepository(db *gorm.DB) *BookingRepository {
 	}
 }
 
+func (br *BookingRepository) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return br.db.WithContext(ctx).Transaction(fn)
+}
+
+// LockFieldForBooking takes a transaction-scoped advisory lock keyed by field, so
+// concurrent bookings on the same field are checked and inserted one at a time.
+func (br *BookingRepository) LockFieldForBooking(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID) error {
+	if tx == nil {
+		tx = br.db
+	}
+
+	return tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", fieldID.String()).Error
+}
+
 func (br *BookingRepository) CreateBooking(ctx context.Context, tx *gorm.DB, booking model.Booking) error {
 	if tx == nil {
 		tx = br.db
@@ -126,6 +157,10 @@ func (br *BookingRepository) GetBookingByID(ctx context.Context, tx *gorm.DB, bo
 		Preload("Field").
 		Preload("Field.Category").
 		Preload("User").
+		Preload("PriceItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Preload("Redemption").
 		Where("booking_id = ?", bookingID).
 		Take(&booking).Error; err != nil {
 		return model.Booking{}, false, err
@@ -142,6 +177,37 @@ func (br *BookingRepository) UpdateBooking(ctx context.Context, tx *gorm.DB, boo
 	return tx.WithContext(ctx).Where("booking_id = ?", booking.BookingID).Updates(&booking).Error
 }
 
+// RescheduleBooking saves the slot and price of a moved booking. Columns are listed
+// explicitly so a payment adjustment that returns to zero is still written.
+func (br *BookingRepository) RescheduleBooking(ctx context.Context, tx *gorm.DB, booking model.Booking) error {
+	if tx == nil {
+		tx = br.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.Booking{}).
+		Where("booking_id = ?", booking.BookingID).
+		Select("field_id", "booking_date", "start_time", "end_time", "total_payment", "payment_adjustment", "reschedule_count", "payment_due_at").
+		Updates(&booking).Error
+}
+
+// ReplaceBookingPriceItems swaps the stored price breakdown of a booking, used when the
+// booking is moved to a slot with a different price.
+func (br *BookingRepository) ReplaceBookingPriceItems(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, items []model.BookingPriceItem) error {
+	if tx == nil {
+		tx = br.db
+	}
+
+	if err := tx.WithContext(ctx).Where("booking_id = ?", bookingID).Delete(&model.BookingPriceItem{}).Error; err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).Create(&items).Error
+}
+
 func (br *BookingRepository) DeleteBooking(ctx context.Context, tx *gorm.DB, bookingID string) error {
 	if tx == nil {
 		tx = br.db
@@ -150,7 +216,10 @@ func (br *BookingRepository) DeleteBooking(ctx context.Context, tx *gorm.DB, boo
 	return tx.WithContext(ctx).Where("booking_id = ?", bookingID).Delete(&model.Booking{}).Error
 }
 
-func (br *BookingRepository) CheckBookingOverlap(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, bookingDate time.Time, startTime, endTime time.Time) (bool, error) {
+// CheckBookingOverlap reports whether the slot collides with another active booking.
+// excludeBookingID lets an existing booking be moved without colliding with itself;
+// pass uuid.Nil for a new booking.
+func (br *BookingRepository) CheckBookingOverlap(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, bookingDate time.Time, startTime, endTime time.Time, excludeBookingID uuid.UUID) (bool, error) {
 	if tx == nil {
 		tx = br.db
 	}
@@ -158,8 +227,9 @@ func (br *BookingRepository) CheckBookingOverlap(ctx context.Context, tx *gorm.D
 	var count int64
 	err := tx.WithContext(ctx).
 		Model(&model.Booking{}).
-		Where("field_id = ? AND booking_date = ? AND status != ?", fieldID, bookingDate, "cancelled").
+		Where("field_id = ? AND booking_date = ? AND status NOT IN ?", fieldID, bookingDate, constants.InactiveBookingStatuses).
 		Where("? < end_time AND ? > start_time", startTime, endTime).
+		Where("booking_id <> ?", excludeBookingID).
 		Count(&count).Error
 
 	if err != nil {
@@ -169,6 +239,19 @@ func (br *BookingRepository) CheckBookingOverlap(ctx context.Context, tx *gorm.D
 	return count > 0, nil
 }
 
+func (br *BookingRepository) GetActiveBookingsByFieldAndDateRange(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, startDate, endDate time.Time) ([]model.Booking, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	var bookings []model.Booking
+	err := tx.WithContext(ctx).
+		Where("field_id = ? AND booking_date BETWEEN ? AND ? AND status NOT IN ?", fieldID, startDate, endDate, constants.InactiveBookingStatuses).
+		Order("start_time").
+		Find(&bookings).Error
+
+	return bookings, err
+}
 
 func (br *BookingRepository) GetWaitingVerificationBookings(ctx context.Context, tx *gorm.DB) ([]model.Booking, error) {
 	if tx == nil {
@@ -179,13 +262,83 @@ func (br *BookingRepository) GetWaitingVerificationBookings(ctx context.Context,
 	err := tx.WithContext(ctx).
 		Preload("Field").
 		Preload("Field.Category").
+		Preload("User").
 		Where("status = ?", "waiting_verification").
-		Order("booking_date, start_time").
+		Order("payment_uploaded_at, booking_date, start_time").
+		Find(&bookings).Error
+
+	return bookings, err
+}
+
+// GetExpiredPendingBookings locks pending bookings whose payment window has passed.
+// Rows already locked by another instance are skipped, so every booking is expired once
+// even when several workers run at the same time. Must be called inside a transaction.
+func (br *BookingRepository) GetExpiredPendingBookings(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]model.Booking, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	var bookings []model.Booking
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND payment_due_at IS NOT NULL AND payment_due_at < ?", "pending", now).
+		Order("payment_due_at").
+		Limit(limit).
 		Find(&bookings).Error
 
 	return bookings, err
 }
 
+// FlagBookingForVerification marks a booking as overdue for review. It only succeeds for
+// the first caller, which lets several instances race on the same booking safely.
+func (br *BookingRepository) FlagBookingForVerification(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, flaggedAt time.Time) (bool, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	res := tx.WithContext(ctx).
+		Model(&model.Booking{}).
+		Where("booking_id = ? AND verification_flagged_at IS NULL", bookingID).
+		Update("verification_flagged_at", flaggedAt)
+
+	return res.RowsAffected > 0, res.Error
+}
+
+// MarkBookingCheckedIn records a check-in. Like FlagBookingForVerification it only
+// succeeds once, so two scans of the same code cannot both check the booking in.
+func (br *BookingRepository) MarkBookingCheckedIn(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, checkedInAt time.Time, checkedInBy uuid.UUID) (bool, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	res := tx.WithContext(ctx).
+		Model(&model.Booking{}).
+		Where("booking_id = ? AND status = ? AND checked_in_at IS NULL", bookingID, "booked").
+		Updates(map[string]interface{}{
+			"checked_in_at": checkedInAt,
+			"checked_in_by": checkedInBy,
+		})
+
+	return res.RowsAffected > 0, res.Error
+}
+
+// GetMissedCheckInBookings locks confirmed bookings that started in the given range and
+// were never checked in.
+func (br *BookingRepository) GetMissedCheckInBookings(ctx context.Context, tx *gorm.DB, startedBefore, startedAfter time.Time, limit int) ([]model.Booking, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	var bookings []model.Booking
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND checked_in_at IS NULL AND start_time < ? AND start_time >= ?", "booked", startedBefore, startedAfter).
+		Order("start_time").
+		Limit(limit).
+		Find(&bookings).Error
+
+	return bookings, err
+}
 
 func (br *BookingRepository) UpdateBookingStatus(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, newStatus string) error {
 	if tx == nil {
@@ -197,3 +350,71 @@ func (br *BookingRepository) UpdateBookingStatus(ctx context.Context, tx *gorm.D
 		Where("booking_id = ?", bookingID).
 		Update("status", newStatus).Error
 }
+
+func (br *BookingRepository) CreateBookingStatusHistory(ctx context.Context, tx *gorm.DB, history model.BookingStatusHistory) error {
+	if tx == nil {
+		tx = br.db
+	}
+
+	return tx.WithContext(ctx).Create(&history).Error
+}
+
+func (br *BookingRepository) GetBookingStatusHistory(ctx context.Context, tx *gorm.DB, bookingID string) ([]model.BookingStatusHistory, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	var histories []model.BookingStatusHistory
+	err := tx.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("changed_at ASC").
+		Find(&histories).Error
+
+	return histories, err
+}
+
+func (br *BookingRepository) CreatePaymentProof(ctx context.Context, tx *gorm.DB, proof model.PaymentProof) error {
+	if tx == nil {
+		tx = br.db
+	}
+
+	return tx.WithContext(ctx).Create(&proof).Error
+}
+
+func (br *BookingRepository) GetPaymentProofs(ctx context.Context, tx *gorm.DB, bookingID string) ([]model.PaymentProof, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	var proofs []model.PaymentProof
+	err := tx.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("uploaded_at ASC").
+		Find(&proofs).Error
+
+	return proofs, err
+}
+
+func (br *BookingRepository) RejectPaymentProof(ctx context.Context, tx *gorm.DB, proof model.PaymentProof) error {
+	if tx == nil {
+		tx = br.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.PaymentProof{}).
+		Where("proof_id = ?", proof.ProofID).
+		Select("rejected_at", "rejected_by", "reject_reason").
+		Updates(&proof).Error
+}
+
+// ClearVerificationFlag resets the SLA flag so a proof uploaded later is timed afresh.
+func (br *BookingRepository) ClearVerificationFlag(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) error {
+	if tx == nil {
+		tx = br.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.Booking{}).
+		Where("booking_id = ?", bookingID).
+		Update("verification_flagged_at", nil).Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fieldreserve/model"
+
+	"gorm.io/gorm"
+)
+
+type (
+	IBookingSeriesRepository interface {
+		CreateBookingSeries(ctx context.Context, tx *gorm.DB, series model.BookingSeries) error
+		GetBookingSeriesByID(ctx context.Context, tx *gorm.DB, seriesID string) (model.BookingSeries, bool, error)
+		UpdateBookingSeries(ctx context.Context, tx *gorm.DB, series model.BookingSeries) error
+	}
+
+	BookingSeriesRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewBookingSeriesRepository(db *gorm.DB) *BookingSeriesRepository {
+	return &BookingSeriesRepository{
+		db: db,
+	}
+}
+
+func (bsr *BookingSeriesRepository) CreateBookingSeries(ctx context.Context, tx *gorm.DB, series model.BookingSeries) error {
+	if tx == nil {
+		tx = bsr.db
+	}
+
+	return tx.WithContext(ctx).Create(&series).Error
+}
+
+func (bsr *BookingSeriesRepository) GetBookingSeriesByID(ctx context.Context, tx *gorm.DB, seriesID string) (model.BookingSeries, bool, error) {
+	if tx == nil {
+		tx = bsr.db
+	}
+
+	var series model.BookingSeries
+	if err := tx.WithContext(ctx).
+		Preload("Field").
+		Preload("Bookings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time ASC")
+		}).
+		Where("series_id = ?", seriesID).
+		Take(&series).Error; err != nil {
+		return model.BookingSeries{}, false, err
+	}
+
+	return series, true, nil
+}
+
+func (bsr *BookingSeriesRepository) UpdateBookingSeries(ctx context.Context, tx *gorm.DB, series model.BookingSeries) error {
+	if tx == nil {
+		tx = bsr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.BookingSeries{}).
+		Where("series_id = ?", series.SeriesID).
+		Select("start_time", "end_time", "status", "cancelled_at").
+		Updates(&series).Error
+}
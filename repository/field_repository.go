@@ -20,6 +20,13 @@ type (
 		GetCategoryByID(ctx context.Context, tx *gorm.DB, categoryID uuid.UUID) (model.Category, error)
 		DeleteField(ctx context.Context, tx *gorm.DB, fieldID string) error
 		GetAllWithSchedules(ctx context.Context, tx *gorm.DB) ([]model.Field, error)
+		WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+		CreateFieldImage(ctx context.Context, tx *gorm.DB, image model.FieldImage) error
+		GetFieldImages(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID) ([]model.FieldImage, error)
+		GetFieldImageByID(ctx context.Context, tx *gorm.DB, fieldID, imageID string) (model.FieldImage, bool, error)
+		UpdateFieldImageOrder(ctx context.Context, tx *gorm.DB, imageID uuid.UUID, sortOrder int) error
+		SetFieldCover(ctx context.Context, tx *gorm.DB, fieldID, imageID uuid.UUID, fileName string) error
+		DeleteFieldImage(ctx context.Context, tx *gorm.DB, imageID uuid.UUID) error
 	}
 
 	FieldRepository struct {
@@ -146,3 +153,77 @@ func (fr *FieldRepository) GetAllWithSchedules(ctx context.Context, tx *gorm.DB)
 		Find(&fields).Error
 	return fields, err
 }
+
+func (fr *FieldRepository) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return fr.db.WithContext(ctx).Transaction(fn)
+}
+
+func (fr *FieldRepository) CreateFieldImage(ctx context.Context, tx *gorm.DB, image model.FieldImage) error {
+	if tx == nil {
+		tx = fr.db
+	}
+
+	return tx.WithContext(ctx).Create(&image).Error
+}
+
+func (fr *FieldRepository) GetFieldImages(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID) ([]model.FieldImage, error) {
+	if tx == nil {
+		tx = fr.db
+	}
+
+	var images []model.FieldImage
+	err := tx.WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Order("sort_order ASC, created_at ASC").
+		Find(&images).Error
+	return images, err
+}
+
+func (fr *FieldRepository) GetFieldImageByID(ctx context.Context, tx *gorm.DB, fieldID, imageID string) (model.FieldImage, bool, error) {
+	if tx == nil {
+		tx = fr.db
+	}
+
+	var image model.FieldImage
+	if err := tx.WithContext(ctx).Where("image_id = ? AND field_id = ?", imageID, fieldID).Take(&image).Error; err != nil {
+		return model.FieldImage{}, false, err
+	}
+
+	return image, true, nil
+}
+
+func (fr *FieldRepository) UpdateFieldImageOrder(ctx context.Context, tx *gorm.DB, imageID uuid.UUID, sortOrder int) error {
+	if tx == nil {
+		tx = fr.db
+	}
+
+	return tx.WithContext(ctx).Model(&model.FieldImage{}).
+		Where("image_id = ?", imageID).
+		Update("sort_order", sortOrder).Error
+}
+
+// SetFieldCover flags imageID as the only cover of the field and mirrors its file name onto
+// the field. Passing uuid.Nil and an empty name clears the cover.
+func (fr *FieldRepository) SetFieldCover(ctx context.Context, tx *gorm.DB, fieldID, imageID uuid.UUID, fileName string) error {
+	if tx == nil {
+		tx = fr.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&model.FieldImage{}).
+		Where("field_id = ?", fieldID).
+		Update("is_cover", gorm.Expr("image_id = ?", imageID)).Error; err != nil {
+		return err
+	}
+
+	return tx.WithContext(ctx).Model(&model.Field{}).
+		Where("field_id = ?", fieldID).
+		Update("field_image", fileName).Error
+}
+
+func (fr *FieldRepository) DeleteFieldImage(ctx context.Context, tx *gorm.DB, imageID uuid.UUID) error {
+	if tx == nil {
+		tx = fr.db
+	}
+
+	return tx.WithContext(ctx).Where("image_id = ?", imageID).Delete(&model.FieldImage{}).Error
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fieldreserve/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IPaymentRepository interface {
+		CreatePayment(ctx context.Context, tx *gorm.DB, payment model.Payment) error
+		GetPaymentByID(ctx context.Context, tx *gorm.DB, paymentID string) (model.Payment, bool, error)
+		GetPaymentByProviderRef(ctx context.Context, tx *gorm.DB, provider, providerRef string) (model.Payment, bool, error)
+		GetPaymentsByBookingID(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) ([]model.Payment, error)
+		UpdatePayment(ctx context.Context, tx *gorm.DB, payment model.Payment) error
+	}
+
+	PaymentRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPaymentRepository(db *gorm.DB) *PaymentRepository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+func (pr *PaymentRepository) CreatePayment(ctx context.Context, tx *gorm.DB, payment model.Payment) error {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	return tx.WithContext(ctx).Omit("Booking").Create(&payment).Error
+}
+
+func (pr *PaymentRepository) GetPaymentByID(ctx context.Context, tx *gorm.DB, paymentID string) (model.Payment, bool, error) {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	var payment model.Payment
+	if err := tx.WithContext(ctx).Where("payment_id = ?", paymentID).Take(&payment).Error; err != nil {
+		return model.Payment{}, false, err
+	}
+
+	return payment, true, nil
+}
+
+// GetPaymentByProviderRef locks the payment row so duplicate webhook deliveries are
+// processed one after another. Must be called inside a transaction.
+func (pr *PaymentRepository) GetPaymentByProviderRef(ctx context.Context, tx *gorm.DB, provider, providerRef string) (model.Payment, bool, error) {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	var payment model.Payment
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("provider = ? AND provider_ref = ?", provider, providerRef).
+		Take(&payment).Error; err != nil {
+		return model.Payment{}, false, err
+	}
+
+	return payment, true, nil
+}
+
+func (pr *PaymentRepository) GetPaymentsByBookingID(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) ([]model.Payment, error) {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	var payments []model.Payment
+	err := tx.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("created_at DESC").
+		Find(&payments).Error
+
+	return payments, err
+}
+
+func (pr *PaymentRepository) UpdatePayment(ctx context.Context, tx *gorm.DB, payment model.Payment) error {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("payment_id = ?", payment.PaymentID).
+		Select("status", "paid_at", "refund_amount", "refunded_at").
+		Updates(&payment).Error
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fieldreserve/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	IPricingRuleRepository interface {
+		CreatePricingRule(ctx context.Context, tx *gorm.DB, rule model.PricingRule) error
+		GetPricingRuleByID(ctx context.Context, tx *gorm.DB, ruleID string) (model.PricingRule, bool, error)
+		GetPricingRulesByFieldID(ctx context.Context, tx *gorm.DB, fieldID string) ([]model.PricingRule, error)
+		GetActivePricingRules(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, date time.Time) ([]model.PricingRule, error)
+		UpdatePricingRule(ctx context.Context, tx *gorm.DB, rule model.PricingRule) error
+		DeletePricingRule(ctx context.Context, tx *gorm.DB, ruleID string) error
+	}
+
+	PricingRuleRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPricingRuleRepository(db *gorm.DB) *PricingRuleRepository {
+	return &PricingRuleRepository{
+		db: db,
+	}
+}
+
+func (pr *PricingRuleRepository) CreatePricingRule(ctx context.Context, tx *gorm.DB, rule model.PricingRule) error {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	return tx.WithContext(ctx).Create(&rule).Error
+}
+
+func (pr *PricingRuleRepository) GetPricingRuleByID(ctx context.Context, tx *gorm.DB, ruleID string) (model.PricingRule, bool, error) {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	var rule model.PricingRule
+	if err := tx.WithContext(ctx).Where("pricing_rule_id = ?", ruleID).Take(&rule).Error; err != nil {
+		return model.PricingRule{}, false, err
+	}
+
+	return rule, true, nil
+}
+
+func (pr *PricingRuleRepository) GetPricingRulesByFieldID(ctx context.Context, tx *gorm.DB, fieldID string) ([]model.PricingRule, error) {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	var rules []model.PricingRule
+	err := tx.WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Order("priority DESC, start_time ASC").
+		Find(&rules).Error
+
+	return rules, err
+}
+
+// GetActivePricingRules returns the rules of a field that apply on the given date,
+// highest priority first.
+func (pr *PricingRuleRepository) GetActivePricingRules(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, date time.Time) ([]model.PricingRule, error) {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	day := date.Format("2006-01-02")
+
+	var rules []model.PricingRule
+	err := tx.WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Where("day_of_week IS NULL OR day_of_week = ?", int(date.Weekday())).
+		Where("valid_from IS NULL OR valid_from <= ?", day).
+		Where("valid_until IS NULL OR valid_until >= ?", day).
+		Order("priority DESC, created_at ASC").
+		Find(&rules).Error
+
+	return rules, err
+}
+
+func (pr *PricingRuleRepository) UpdatePricingRule(ctx context.Context, tx *gorm.DB, rule model.PricingRule) error {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	// Columns are selected explicitly so nil optional values (day, validity dates) are
+	// written as NULL; the service decides when a field is cleared or left unchanged.
+	return tx.WithContext(ctx).
+		Model(&model.PricingRule{}).
+		Where("pricing_rule_id = ?", rule.PricingRuleID).
+		Select("name", "day_of_week", "start_time", "end_time", "valid_from", "valid_until", "price_per_hour", "priority").
+		Updates(&rule).Error
+}
+
+func (pr *PricingRuleRepository) DeletePricingRule(ctx context.Context, tx *gorm.DB, ruleID string) error {
+	if tx == nil {
+		tx = pr.db
+	}
+
+	return tx.WithContext(ctx).Where("pricing_rule_id = ?", ruleID).Delete(&model.PricingRule{}).Error
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"fieldreserve/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ITokenRepository interface {
+		WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+		CreateTokenFamily(ctx context.Context, tx *gorm.DB, family model.TokenFamily) error
+		GetTokenFamilyByID(ctx context.Context, tx *gorm.DB, familyID uuid.UUID) (model.TokenFamily, bool, error)
+		GetActiveTokenFamilies(ctx context.Context, tx *gorm.DB, userID uuid.UUID) ([]model.TokenFamily, error)
+		TouchTokenFamily(ctx context.Context, tx *gorm.DB, familyID uuid.UUID, userAgent, ipAddress string, expiresAt time.Time) error
+		RevokeTokenFamily(ctx context.Context, tx *gorm.DB, familyID uuid.UUID, reason string) error
+		RevokeUserTokenFamilies(ctx context.Context, tx *gorm.DB, userID, keep uuid.UUID, reason string) ([]uuid.UUID, error)
+		CreateRefreshToken(ctx context.Context, tx *gorm.DB, token model.RefreshToken) error
+		GetRefreshTokenByID(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) (model.RefreshToken, bool, error)
+		MarkRefreshTokenUsed(ctx context.Context, tx *gorm.DB, tokenID, replacedBy uuid.UUID) (bool, error)
+		CreateUserToken(ctx context.Context, tx *gorm.DB, token model.UserToken) error
+		GetUserTokenByHash(ctx context.Context, tx *gorm.DB, purpose, tokenHash string) (model.UserToken, bool, error)
+		ConsumeUserToken(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) (bool, error)
+		InvalidateUserTokens(ctx context.Context, tx *gorm.DB, userID uuid.UUID, purpose string) error
+		CountUserTokensSince(ctx context.Context, tx *gorm.DB, userID uuid.UUID, purpose string, since time.Time) (int64, error)
+	}
+
+	TokenRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewTokenRepository(db *gorm.DB) *TokenRepository {
+	return &TokenRepository{
+		db: db,
+	}
+}
+
+func (tr *TokenRepository) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return tr.db.WithContext(ctx).Transaction(fn)
+}
+
+func (tr *TokenRepository) CreateTokenFamily(ctx context.Context, tx *gorm.DB, family model.TokenFamily) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).Create(&family).Error
+}
+
+func (tr *TokenRepository) GetTokenFamilyByID(ctx context.Context, tx *gorm.DB, familyID uuid.UUID) (model.TokenFamily, bool, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	var family model.TokenFamily
+	if err := tx.WithContext(ctx).Where("family_id = ?", familyID).Take(&family).Error; err != nil {
+		return model.TokenFamily{}, false, err
+	}
+
+	return family, true, nil
+}
+
+// GetActiveTokenFamilies returns the user's sessions that can still be refreshed, most
+// recently used first.
+func (tr *TokenRepository) GetActiveTokenFamilies(ctx context.Context, tx *gorm.DB, userID uuid.UUID) ([]model.TokenFamily, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	var families []model.TokenFamily
+	err := tx.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&families).Error
+
+	return families, err
+}
+
+// TouchTokenFamily records a refresh: where it came from and how long the session now lasts.
+func (tr *TokenRepository) TouchTokenFamily(ctx context.Context, tx *gorm.DB, familyID uuid.UUID, userAgent, ipAddress string, expiresAt time.Time) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.TokenFamily{}).
+		Where("family_id = ?", familyID).
+		Updates(map[string]interface{}{
+			"user_agent":   userAgent,
+			"ip_address":   ipAddress,
+			"last_seen_at": time.Now(),
+			"expires_at":   expiresAt,
+		}).Error
+}
+
+// RevokeTokenFamily is idempotent: a family keeps the time and reason of its first revocation.
+func (tr *TokenRepository) RevokeTokenFamily(ctx context.Context, tx *gorm.DB, familyID uuid.UUID, reason string) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.TokenFamily{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Updates(map[string]interface{}{
+			"revoked_at":    time.Now(),
+			"revoke_reason": reason,
+		}).Error
+}
+
+// RevokeUserTokenFamilies revokes every active session of the user except keep, which may
+// be uuid.Nil, and returns the IDs it revoked.
+func (tr *TokenRepository) RevokeUserTokenFamilies(ctx context.Context, tx *gorm.DB, userID, keep uuid.UUID, reason string) ([]uuid.UUID, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	var familyIDs []uuid.UUID
+	err := tx.WithContext(ctx).
+		Model(&model.TokenFamily{}).
+		Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL AND expires_at > ?", userID, keep, time.Now()).
+		Pluck("family_id", &familyIDs).Error
+	if err != nil || len(familyIDs) == 0 {
+		return nil, err
+	}
+
+	err = tx.WithContext(ctx).
+		Model(&model.TokenFamily{}).
+		Where("family_id IN ? AND revoked_at IS NULL", familyIDs).
+		Updates(map[string]interface{}{
+			"revoked_at":    time.Now(),
+			"revoke_reason": reason,
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return familyIDs, nil
+}
+
+func (tr *TokenRepository) CreateRefreshToken(ctx context.Context, tx *gorm.DB, token model.RefreshToken) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).Create(&token).Error
+}
+
+func (tr *TokenRepository) GetRefreshTokenByID(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) (model.RefreshToken, bool, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	var token model.RefreshToken
+	if err := tx.WithContext(ctx).Where("token_id = ?", tokenID).Take(&token).Error; err != nil {
+		return model.RefreshToken{}, false, err
+	}
+
+	return token, true, nil
+}
+
+// MarkRefreshTokenUsed reports false when the token was already used, so two requests
+// racing with the same token cannot both rotate it.
+func (tr *TokenRepository) MarkRefreshTokenUsed(ctx context.Context, tx *gorm.DB, tokenID, replacedBy uuid.UUID) (bool, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	res := tx.WithContext(ctx).
+		Model(&model.RefreshToken{}).
+		Where("token_id = ? AND used_at IS NULL", tokenID).
+		Updates(map[string]interface{}{
+			"used_at":     time.Now(),
+			"replaced_by": replacedBy,
+		})
+
+	return res.RowsAffected > 0, res.Error
+}
+
+func (tr *TokenRepository) CreateUserToken(ctx context.Context, tx *gorm.DB, token model.UserToken) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).Create(&token).Error
+}
+
+func (tr *TokenRepository) GetUserTokenByHash(ctx context.Context, tx *gorm.DB, purpose, tokenHash string) (model.UserToken, bool, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	var token model.UserToken
+	if err := tx.WithContext(ctx).Where("purpose = ? AND token_hash = ?", purpose, tokenHash).Take(&token).Error; err != nil {
+		return model.UserToken{}, false, err
+	}
+
+	return token, true, nil
+}
+
+// ConsumeUserToken marks an unused, unexpired token as used. It reports false when the
+// token was already used or has expired, so a link works exactly once.
+func (tr *TokenRepository) ConsumeUserToken(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) (bool, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	now := time.Now()
+	res := tx.WithContext(ctx).
+		Model(&model.UserToken{}).
+		Where("token_id = ? AND used_at IS NULL AND expires_at > ?", tokenID, now).
+		Update("used_at", now)
+
+	return res.RowsAffected > 0, res.Error
+}
+
+// InvalidateUserTokens uses up the user's outstanding tokens for purpose, so only the most
+// recently mailed link works.
+func (tr *TokenRepository) InvalidateUserTokens(ctx context.Context, tx *gorm.DB, userID uuid.UUID, purpose string) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
+
+func (tr *TokenRepository) CountUserTokensSince(ctx context.Context, tx *gorm.DB, userID uuid.UUID, purpose string, since time.Time) (int64, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	var count int64
+	err := tx.WithContext(ctx).
+		Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND created_at > ?", userID, purpose, since).
+		Count(&count).Error
+
+	return count, err
+}
@@ -6,6 +6,7 @@ import (
 	"fieldreserve/model"
 	"math"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -17,6 +18,7 @@ type (
 		GetAllUserWithPagination(ctx context.Context, tx *gorm.DB, req dto.UserPaginationRequest) (dto.UserPaginationRepositoryResponse, error)
 		CreateUser(ctx context.Context, tx *gorm.DB, user model.User) error
 		UpdateUser(ctx context.Context, tx *gorm.DB, user model.User) error
+		SetEmailVerifiedAt(ctx context.Context, tx *gorm.DB, userID string, verifiedAt *time.Time) error
 		DeleteUserByID(ctx context.Context, tx *gorm.DB, userID string) error
 	}
 
@@ -123,6 +125,16 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, tx *gorm.DB, user mode
 	return tx.WithContext(ctx).Where("user_id = ?", user.UserID).Updates(&user).Error
 }
 
+// SetEmailVerifiedAt is separate from UpdateUser because Updates skips nil fields, and a
+// changed email address has to become unverified again.
+func (ur *UserRepository) SetEmailVerifiedAt(ctx context.Context, tx *gorm.DB, userID string, verifiedAt *time.Time) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Model(&model.User{}).Where("user_id = ?", userID).Update("email_verified_at", verifiedAt).Error
+}
+
 func (ur *UserRepository) DeleteUserByID(ctx context.Context, tx *gorm.DB, userID string) error {
 	if tx == nil {
 		tx = ur.db
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IVoucherRepository interface {
+		CreateVoucher(ctx context.Context, tx *gorm.DB, voucher model.Voucher) error
+		GetVoucherByID(ctx context.Context, tx *gorm.DB, voucherID string) (model.Voucher, bool, error)
+		GetVoucherByCode(ctx context.Context, tx *gorm.DB, code string) (model.Voucher, bool, error)
+		GetAllVouchers(ctx context.Context, tx *gorm.DB) ([]model.Voucher, error)
+		UpdateVoucher(ctx context.Context, tx *gorm.DB, voucher model.Voucher) error
+		DeleteVoucher(ctx context.Context, tx *gorm.DB, voucherID string) error
+		LockVoucher(ctx context.Context, tx *gorm.DB, voucherID uuid.UUID) error
+		CountVoucherRedemptions(ctx context.Context, tx *gorm.DB, voucherID uuid.UUID, userID *uuid.UUID) (int64, error)
+		CreateVoucherRedemption(ctx context.Context, tx *gorm.DB, redemption model.VoucherRedemption) error
+		GetRedemptionByBookingID(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) (model.VoucherRedemption, bool, error)
+		UpdateRedemptionDiscount(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, discountAmount float64) error
+		DeleteRedemptionByBookingID(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) error
+	}
+
+	VoucherRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewVoucherRepository(db *gorm.DB) *VoucherRepository {
+	return &VoucherRepository{
+		db: db,
+	}
+}
+
+func (vr *VoucherRepository) CreateVoucher(ctx context.Context, tx *gorm.DB, voucher model.Voucher) error {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	return tx.WithContext(ctx).Create(&voucher).Error
+}
+
+func (vr *VoucherRepository) GetVoucherByID(ctx context.Context, tx *gorm.DB, voucherID string) (model.Voucher, bool, error) {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	var voucher model.Voucher
+	if err := tx.WithContext(ctx).
+		Preload("Fields").
+		Preload("Categories").
+		Where("voucher_id = ?", voucherID).
+		Take(&voucher).Error; err != nil {
+		return model.Voucher{}, false, err
+	}
+
+	return voucher, true, nil
+}
+
+func (vr *VoucherRepository) GetVoucherByCode(ctx context.Context, tx *gorm.DB, code string) (model.Voucher, bool, error) {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	var voucher model.Voucher
+	if err := tx.WithContext(ctx).
+		Preload("Fields").
+		Preload("Categories").
+		Where("code = ?", code).
+		Take(&voucher).Error; err != nil {
+		return model.Voucher{}, false, err
+	}
+
+	return voucher, true, nil
+}
+
+func (vr *VoucherRepository) GetAllVouchers(ctx context.Context, tx *gorm.DB) ([]model.Voucher, error) {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	var vouchers []model.Voucher
+	err := tx.WithContext(ctx).
+		Preload("Fields").
+		Preload("Categories").
+		Order("created_at DESC").
+		Find(&vouchers).Error
+
+	return vouchers, err
+}
+
+func (vr *VoucherRepository) UpdateVoucher(ctx context.Context, tx *gorm.DB, voucher model.Voucher) error {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Columns are selected explicitly so limits and validity dates can be cleared.
+		if err := tx.Model(&model.Voucher{}).
+			Where("voucher_id = ?", voucher.VoucherID).
+			Select("description", "discount_type", "discount_value", "max_discount", "min_spend",
+				"valid_from", "valid_until", "usage_limit", "per_user_limit", "is_active").
+			Updates(&voucher).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&voucher).Association("Fields").Replace(voucher.Fields); err != nil {
+			return err
+		}
+
+		return tx.Model(&voucher).Association("Categories").Replace(voucher.Categories)
+	})
+}
+
+func (vr *VoucherRepository) DeleteVoucher(ctx context.Context, tx *gorm.DB, voucherID string) error {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	return tx.WithContext(ctx).Where("voucher_id = ?", voucherID).Delete(&model.Voucher{}).Error
+}
+
+// LockVoucher locks the voucher row until the transaction ends, so concurrent checkouts
+// cannot both take its last use. Must be called inside a transaction.
+func (vr *VoucherRepository) LockVoucher(ctx context.Context, tx *gorm.DB, voucherID uuid.UUID) error {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	var voucher model.Voucher
+	return tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("voucher_id").
+		Where("voucher_id = ?", voucherID).
+		Take(&voucher).Error
+}
+
+// CountVoucherRedemptions counts redemptions on bookings that still hold their slot, so a
+// cancelled booking gives its use back. When userID is set only that user's are counted.
+func (vr *VoucherRepository) CountVoucherRedemptions(ctx context.Context, tx *gorm.DB, voucherID uuid.UUID, userID *uuid.UUID) (int64, error) {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	query := tx.WithContext(ctx).
+		Model(&model.VoucherRedemption{}).
+		Joins("JOIN bookings ON bookings.booking_id = voucher_redemptions.booking_id AND bookings.deleted_at IS NULL").
+		Where("voucher_redemptions.voucher_id = ?", voucherID).
+		Where("bookings.status NOT IN ?", constants.InactiveBookingStatuses)
+
+	if userID != nil {
+		query = query.Where("voucher_redemptions.user_id = ?", *userID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+
+	return count, err
+}
+
+func (vr *VoucherRepository) CreateVoucherRedemption(ctx context.Context, tx *gorm.DB, redemption model.VoucherRedemption) error {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	return tx.WithContext(ctx).Omit("Voucher").Create(&redemption).Error
+}
+
+func (vr *VoucherRepository) GetRedemptionByBookingID(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) (model.VoucherRedemption, bool, error) {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	// The voucher is loaded even when deleted, a redemption keeps its terms.
+	var redemption model.VoucherRedemption
+	if err := tx.WithContext(ctx).
+		Preload("Voucher", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Preload("Voucher.Fields").
+		Preload("Voucher.Categories").
+		Where("booking_id = ?", bookingID).
+		Take(&redemption).Error; err != nil {
+		return model.VoucherRedemption{}, false, err
+	}
+
+	return redemption, true, nil
+}
+
+func (vr *VoucherRepository) UpdateRedemptionDiscount(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, discountAmount float64) error {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.VoucherRedemption{}).
+		Where("booking_id = ?", bookingID).
+		Update("discount_amount", discountAmount).Error
+}
+
+func (vr *VoucherRepository) DeleteRedemptionByBookingID(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) error {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	return tx.WithContext(ctx).Where("booking_id = ?", bookingID).Delete(&model.VoucherRedemption{}).Error
+}
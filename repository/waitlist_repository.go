@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IWaitlistRepository interface {
+		CreateWaitlistEntry(ctx context.Context, tx *gorm.DB, entry model.WaitlistEntry) error
+		GetWaitlistEntryByID(ctx context.Context, tx *gorm.DB, waitlistID string) (model.WaitlistEntry, bool, error)
+		GetWaitlistEntriesByUserID(ctx context.Context, tx *gorm.DB, userID uuid.UUID) ([]model.WaitlistEntry, error)
+		UpdateWaitlistEntry(ctx context.Context, tx *gorm.DB, entry model.WaitlistEntry) error
+		HasOpenWaitlistEntry(ctx context.Context, tx *gorm.DB, userID, fieldID uuid.UUID, startTime, endTime time.Time) (bool, error)
+		GetWaitingEntriesForSlot(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, startTime, endTime, startsAfter time.Time) ([]model.WaitlistEntry, error)
+		HasOverlappingHold(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, startTime, endTime time.Time, excludeUserID uuid.UUID, now time.Time) (bool, error)
+		FulfillWaitlistEntries(ctx context.Context, tx *gorm.DB, userID, fieldID uuid.UUID, startTime, endTime time.Time) error
+		GetLapsedHolds(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]model.WaitlistEntry, error)
+		GetWaitlistDemand(ctx context.Context, tx *gorm.DB, fieldID string, now time.Time) ([]dto.WaitlistDemandRow, error)
+	}
+
+	WaitlistRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewWaitlistRepository(db *gorm.DB) *WaitlistRepository {
+	return &WaitlistRepository{
+		db: db,
+	}
+}
+
+func (wr *WaitlistRepository) CreateWaitlistEntry(ctx context.Context, tx *gorm.DB, entry model.WaitlistEntry) error {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	return tx.WithContext(ctx).Create(&entry).Error
+}
+
+func (wr *WaitlistRepository) GetWaitlistEntryByID(ctx context.Context, tx *gorm.DB, waitlistID string) (model.WaitlistEntry, bool, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	var entry model.WaitlistEntry
+	if err := tx.WithContext(ctx).Where("waitlist_id = ?", waitlistID).Take(&entry).Error; err != nil {
+		return model.WaitlistEntry{}, false, err
+	}
+
+	return entry, true, nil
+}
+
+func (wr *WaitlistRepository) GetWaitlistEntriesByUserID(ctx context.Context, tx *gorm.DB, userID uuid.UUID) ([]model.WaitlistEntry, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	var entries []model.WaitlistEntry
+	err := tx.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("start_time DESC").
+		Find(&entries).Error
+
+	return entries, err
+}
+
+func (wr *WaitlistRepository) UpdateWaitlistEntry(ctx context.Context, tx *gorm.DB, entry model.WaitlistEntry) error {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.WaitlistEntry{}).
+		Where("waitlist_id = ?", entry.WaitlistID).
+		Select("status", "offered_at", "hold_expires_at").
+		Updates(&entry).Error
+}
+
+func (wr *WaitlistRepository) HasOpenWaitlistEntry(ctx context.Context, tx *gorm.DB, userID, fieldID uuid.UUID, startTime, endTime time.Time) (bool, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	var count int64
+	err := tx.WithContext(ctx).
+		Model(&model.WaitlistEntry{}).
+		Where("user_id = ? AND field_id = ? AND status IN ?", userID, fieldID, constants.OpenWaitlistStatuses).
+		Where("start_time = ? AND end_time = ?", startTime, endTime).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// GetWaitingEntriesForSlot returns waiting entries overlapping the released range that start
+// after startsAfter, oldest first. Rows locked by another instance are skipped. Must be
+// called inside a transaction.
+func (wr *WaitlistRepository) GetWaitingEntriesForSlot(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, startTime, endTime, startsAfter time.Time) ([]model.WaitlistEntry, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	var entries []model.WaitlistEntry
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("field_id = ? AND status = ? AND start_time > ?", fieldID, constants.ENUM_WAITLIST_WAITING, startsAfter).
+		Where("? < end_time AND ? > start_time", startTime, endTime).
+		Order("created_at ASC").
+		Find(&entries).Error
+
+	return entries, err
+}
+
+func (wr *WaitlistRepository) HasOverlappingHold(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, startTime, endTime time.Time, excludeUserID uuid.UUID, now time.Time) (bool, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	var count int64
+	err := tx.WithContext(ctx).
+		Model(&model.WaitlistEntry{}).
+		Where("field_id = ? AND status = ? AND hold_expires_at > ?", fieldID, constants.ENUM_WAITLIST_OFFERED, now).
+		Where("? < end_time AND ? > start_time", startTime, endTime).
+		Where("user_id <> ?", excludeUserID).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+func (wr *WaitlistRepository) FulfillWaitlistEntries(ctx context.Context, tx *gorm.DB, userID, fieldID uuid.UUID, startTime, endTime time.Time) error {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&model.WaitlistEntry{}).
+		Where("user_id = ? AND field_id = ? AND status IN ?", userID, fieldID, constants.OpenWaitlistStatuses).
+		Where("? < end_time AND ? > start_time", startTime, endTime).
+		Update("status", constants.ENUM_WAITLIST_FULFILLED).Error
+}
+
+// GetLapsedHolds locks offered entries whose hold has run out, skipping rows another
+// instance is already handling. Must be called inside a transaction.
+func (wr *WaitlistRepository) GetLapsedHolds(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]model.WaitlistEntry, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	var entries []model.WaitlistEntry
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND hold_expires_at <= ?", constants.ENUM_WAITLIST_OFFERED, now).
+		Order("hold_expires_at").
+		Limit(limit).
+		Find(&entries).Error
+
+	return entries, err
+}
+
+func (wr *WaitlistRepository) GetWaitlistDemand(ctx context.Context, tx *gorm.DB, fieldID string, now time.Time) ([]dto.WaitlistDemandRow, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	query := tx.WithContext(ctx).
+		Table("waitlist_entries").
+		Select(`waitlist_entries.field_id, fields.field_name, waitlist_entries.booking_date,
+			waitlist_entries.start_time, waitlist_entries.end_time,
+			COUNT(*) FILTER (WHERE waitlist_entries.status = ?) AS waiting_count,
+			COUNT(*) FILTER (WHERE waitlist_entries.status = ?) AS offered_count`,
+			constants.ENUM_WAITLIST_WAITING, constants.ENUM_WAITLIST_OFFERED).
+		Joins("JOIN fields ON fields.field_id = waitlist_entries.field_id").
+		Where("waitlist_entries.status IN ? AND waitlist_entries.start_time > ?", constants.OpenWaitlistStatuses, now).
+		Where("waitlist_entries.deleted_at IS NULL")
+
+	if fieldID != "" {
+		query = query.Where("waitlist_entries.field_id = ?", fieldID)
+	}
+
+	var rows []dto.WaitlistDemandRow
+	err := query.
+		Group("waitlist_entries.field_id, fields.field_name, waitlist_entries.booking_date, waitlist_entries.start_time, waitlist_entries.end_time").
+		Order("fields.field_name, waitlist_entries.start_time").
+		Scan(&rows).Error
+
+	return rows, err
+}
@@ -0,0 +1,52 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore keeps revocations in process memory. They are lost on restart and not shared
+// between replicas, so it only suits a single instance or local development.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	revoked map[uuid.UUID]time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		revoked: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (ms *MemoryStore) Revoke(ctx context.Context, id uuid.UUID, until time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	now := time.Now()
+	for key, expiresAt := range ms.revoked {
+		if !expiresAt.After(now) {
+			delete(ms.revoked, key)
+		}
+	}
+
+	if current, ok := ms.revoked[id]; !ok || until.After(current) {
+		ms.revoked[id] = until
+	}
+	return nil
+}
+
+func (ms *MemoryStore) IsRevoked(ctx context.Context, ids ...uuid.UUID) (bool, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	now := time.Now()
+	for _, id := range ids {
+		if expiresAt, ok := ms.revoked[id]; ok && expiresAt.After(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
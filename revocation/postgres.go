@@ -0,0 +1,53 @@
+package revocation
+
+import (
+	"context"
+	"fieldreserve/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresStore keeps revocations in the revoked_tokens table.
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{
+		db: db,
+	}
+}
+
+func (ps *PostgresStore) Revoke(ctx context.Context, id uuid.UUID, until time.Time) error {
+	return ps.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Revocations are rare, so expired rows are cleared here instead of by a worker.
+		if err := tx.Where("expires_at <= ?", time.Now()).Delete(&model.RevokedToken{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "token_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"expires_at": gorm.Expr("GREATEST(revoked_tokens.expires_at, EXCLUDED.expires_at)")}),
+		}).Create(&model.RevokedToken{TokenID: id, ExpiresAt: until}).Error
+	})
+}
+
+func (ps *PostgresStore) IsRevoked(ctx context.Context, ids ...uuid.UUID) (bool, error) {
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	var count int64
+	err := ps.db.WithContext(ctx).
+		Model(&model.RevokedToken{}).
+		Where("token_id IN ? AND expires_at > ?", ids, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,40 @@
+// Package revocation remembers JWTs that were revoked before they expired. Tokens are
+// verified without a database lookup, so the authentication middleware asks this store
+// about every request.
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Store interface {
+	// Revoke marks id, a token's jti or session ID, as revoked until the given time. After
+	// that every token carrying it has expired anyway and the entry can be forgotten.
+	Revoke(ctx context.Context, id uuid.UUID, until time.Time) error
+	// IsRevoked reports whether any of ids is revoked.
+	IsRevoked(ctx context.Context, ids ...uuid.UUID) (bool, error)
+}
+
+// NewFromEnv builds the store named by REVOCATION_STORE, defaulting to Postgres so every
+// API replica sees the same revocations.
+func NewFromEnv(db *gorm.DB) (Store, error) {
+	driver := os.Getenv("REVOCATION_STORE")
+	if driver == "" {
+		driver = "postgres"
+	}
+
+	switch driver {
+	case "postgres":
+		return NewPostgresStore(db), nil
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown revocation store %q", driver)
+	}
+}
@@ -10,13 +10,15 @@ import (
 )
 
 func AdminRoutes(r *gin.Engine, userController controller.IUserController, categoryController controller.ICategoryController, fieldcontroller controller.IFieldController, scheduleController controller.IScheduleController, bookingController controller.IBookingController,
-	jwtService service.InterfaceJWTService) {
+	pricingRuleController controller.IPricingRuleController, waitlistController controller.IWaitlistController, voucherController controller.IVoucherController, paymentController controller.IPaymentController, jwtService service.InterfaceJWTService) {
 	admin := r.Group("/api/admin")
 	admin.Use(middleware.Authentication(jwtService))
 	admin.Use(middleware.AuthorizeRole(constants.ENUM_ROLE_ADMIN))
 
 	// User management
 	admin.GET("/get-all-users", userController.GetAllUser)
+	admin.PATCH("/update-user-role/:id", userController.UpdateUserRole)
+	admin.POST("/revoke-user-sessions/:id", userController.RevokeUserSessions)
 
 	// Category management
 	admin.GET("/get-category/:id", categoryController.GetCategoryByID)
@@ -28,6 +30,9 @@ func AdminRoutes(r *gin.Engine, userController controller.IUserController, categ
 	admin.POST("/create-field", fieldcontroller.CreateField)
 	admin.PATCH("/update-field/:id", fieldcontroller.UpdateField)
 	admin.DELETE("/delete-field/:id", fieldcontroller.DeleteField)
+	admin.POST("/add-field-image/:id", fieldcontroller.AddFieldImage)
+	admin.PATCH("/reorder-field-images/:id", fieldcontroller.ReorderFieldImages)
+	admin.DELETE("/delete-field-image/:id/:image_id", fieldcontroller.DeleteFieldImage)
 
 	// Schedule Management
 	admin.POST("/create-schedule", scheduleController.CreateSchedule)
@@ -35,10 +40,34 @@ func AdminRoutes(r *gin.Engine, userController controller.IUserController, categ
 	admin.DELETE("/delete-schedule/:id", scheduleController.DeleteSchedule)
 	admin.GET("/get-all-schedule", scheduleController.GetAllSchedule)
 
+	// Pricing Rule Management
+	admin.POST("/create-pricing-rule", pricingRuleController.CreatePricingRule)
+	admin.GET("/get-pricing-rule/:id", pricingRuleController.GetPricingRuleByID)
+	admin.GET("/get-pricing-rules-by-field/:field_id", pricingRuleController.GetPricingRulesByFieldID)
+	admin.PATCH("/update-pricing-rule/:id", pricingRuleController.UpdatePricingRule)
+	admin.DELETE("/delete-pricing-rule/:id", pricingRuleController.DeletePricingRule)
+
 	// Booking Management
 	admin.GET("/get-all-bookings", bookingController.GetAllBooking)
 	admin.GET("/get-booking/:id", bookingController.GetBookingByID)
 	admin.PATCH("/update-booking/:id", bookingController.UpdateStatusBooking)
+	admin.GET("/get-booking-timeline/:id", bookingController.GetBookingTimeline)
 	admin.DELETE("/delete-booking/:id", bookingController.DeleteBooking)
+	admin.GET("/get-verification-queue", bookingController.GetVerificationQueue)
+	admin.POST("/reject-payment-proof/:id", bookingController.RejectPaymentProof)
+	admin.GET("/get-payment-proof-url/:id", bookingController.GetPaymentProofURL)
+
+	// Payment Management
+	admin.POST("/refund-payment/:id", paymentController.RefundPayment)
+
+	// Voucher Management
+	admin.POST("/create-voucher", voucherController.CreateVoucher)
+	admin.GET("/get-all-vouchers", voucherController.GetAllVouchers)
+	admin.GET("/get-voucher/:id", voucherController.GetVoucherByID)
+	admin.PATCH("/update-voucher/:id", voucherController.UpdateVoucher)
+	admin.DELETE("/delete-voucher/:id", voucherController.DeleteVoucher)
+
+	// Waitlist Management
+	admin.GET("/get-waitlist-demand", waitlistController.GetWaitlistDemand)
 
 }
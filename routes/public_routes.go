@@ -6,8 +6,25 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func PublicRoutes(r *gin.Engine, userController controller.IUserController) {
+func PublicRoutes(r *gin.Engine, userController controller.IUserController, fileController controller.IFileController, paymentController controller.IPaymentController) {
 	public := r.Group("/api/users")
 	public.POST("/register", userController.CreateUser)
 	public.POST("/login", userController.GetUserByEmail)
+	// Access tokens are short-lived, so refreshing can't require one.
+	public.POST("/refresh", userController.RefreshToken)
+	public.POST("/forgot-password", userController.ForgotPassword)
+	public.POST("/reset-password", userController.ResetPassword)
+	public.POST("/verify-email", userController.VerifyEmail)
+
+	// Payment gateways call back here; requests are authenticated by their signature.
+	payments := r.Group("/api/payments")
+	payments.POST("/webhook/:provider", paymentController.HandleWebhook)
+
+	// Signed links handed out by the local storage backend; the signature stands in for a session.
+	files := r.Group("/api/files")
+	files.GET("/*key", fileController.GetSignedFile)
+
+	// Field photos keep their old public URLs but are read from the storage backend.
+	assets := r.Group("/assets")
+	assets.GET("/fields/:name", fileController.GetFieldImage)
 }
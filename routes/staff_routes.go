@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"fieldreserve/constants"
+	"fieldreserve/controller"
+	"fieldreserve/middleware"
+	"fieldreserve/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StaffRoutes serve venue staff at the front desk. Admins can use them as well.
+func StaffRoutes(r *gin.Engine, bookingController controller.IBookingController, jwtService service.InterfaceJWTService) {
+	staff := r.Group("/api/staff")
+	staff.Use(middleware.Authentication(jwtService))
+	staff.Use(middleware.AuthorizeRole(constants.ENUM_ROLE_STAFF, constants.ENUM_ROLE_ADMIN))
+
+	// Check-in
+	staff.POST("/check-in", bookingController.CheckInBooking)
+}
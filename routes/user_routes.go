@@ -1,9 +1,11 @@
 package routes
 
 import (
+	"fieldreserve/constants"
 	"fieldreserve/controller"
 	"fieldreserve/middleware"
 	"fieldreserve/service"
+	"os"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +17,9 @@ func UserRoutes(
 	fieldController controller.IFieldController,
 	scheduleController controller.IScheduleController,
 	bookingController controller.IBookingController,
+	bookingSeriesController controller.IBookingSeriesController,
+	waitlistController controller.IWaitlistController,
+	paymentController controller.IPaymentController,
 	jwtService service.InterfaceJWTService,
 ) {
 	user := r.Group("/api/users")
@@ -24,6 +29,10 @@ func UserRoutes(
 	user.PATCH("/update-profile/:id", userController.UpdateUser)
 	user.GET("/get-detail-user/:id", userController.GetUserByID)
 	user.DELETE("/delete-profile/:id", userController.DeleteUser)
+	user.POST("/logout", userController.Logout)
+	user.POST("/logout-all", userController.LogoutAll)
+	user.GET("/sessions", userController.GetSessions)
+	user.POST("/resend-verification", userController.ResendEmailVerification)
 
 	// --- Category Routes ---
 	user.GET("/get-all-categories", categoryController.GetAllCatgory)
@@ -36,9 +45,39 @@ func UserRoutes(
 	user.GET("/get-schedule-by-id/:id", scheduleController.GetScheduleByID)
 	user.GET("/get-schedules-by-field/:field_id", scheduleController.GetSchedulesByFieldID)
 	user.GET("/get-schedule-by-day/:field_id/day/:day", scheduleController.GetScheduleByFieldIDAndDay)
+	user.GET("/get-availability/:field_id", scheduleController.GetFieldAvailability)
 
+	user.POST("/quote", bookingController.QuoteBooking)
 	user.POST("/create-booking", bookingController.CreateBooking)
 	user.GET("booking/:id", bookingController.GetBookingByID)
 	user.GET("/bookings", bookingController.GetUserBookingHistory)
 	user.GET("/booking/:id/invoice", bookingController.DownloadInvoice)
+	user.GET("/booking/:id/payment-qr", bookingController.GetPaymentQR)
+	user.POST("/booking/:id/payment-proof", bookingController.UploadPaymentProof)
+	user.GET("/booking/:id/payment-proof", bookingController.GetPaymentProof)
+	user.GET("/booking/:id/timeline", bookingController.GetBookingTimeline)
+	user.POST("/booking/:id/cancel", bookingController.CancelBooking)
+	user.POST("/booking/:id/reschedule", bookingController.RescheduleBooking)
+
+	// --- Booking Series Routes ---
+	user.POST("/create-booking-series", bookingSeriesController.CreateBookingSeries)
+	user.GET("/booking-series/:id", bookingSeriesController.GetBookingSeriesByID)
+	user.PATCH("/booking-series/:id", bookingSeriesController.UpdateBookingSeries)
+	user.POST("/booking-series/:id/cancel", bookingSeriesController.CancelBookingSeries)
+	user.PATCH("/booking-series/:id/occurrences/:booking_id", bookingSeriesController.UpdateSeriesOccurrence)
+	user.POST("/booking-series/:id/occurrences/:booking_id/cancel", bookingSeriesController.CancelSeriesOccurrence)
+
+	// --- Waitlist Routes ---
+	user.POST("/join-waitlist", waitlistController.JoinWaitlist)
+	user.GET("/waitlist", waitlistController.GetMyWaitlist)
+	user.DELETE("/waitlist/:id", waitlistController.LeaveWaitlist)
+
+	// --- Payment Routes ---
+	user.POST("/booking/:id/pay", paymentController.CreatePayment)
+	user.GET("/booking/:id/payments", paymentController.GetBookingPayments)
+
+	// Simulating marks a charge paid without moving money, so it only exists outside production.
+	if os.Getenv("APP_ENV") != constants.ENUM_RUN_PRODUCTION {
+		user.POST("/payments/:id/simulate", paymentController.SimulatePayment)
+	}
 }
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/utils"
+
+	"github.com/google/uuid"
+)
+
+// actor is the authenticated user behind a request, resolved from the token the
+// authentication middleware stores on the request context.
+type actor struct {
+	UserID uuid.UUID
+	Role   string
+}
+
+func actorFromContext(ctx context.Context, jwtService InterfaceJWTService) (actor, error) {
+	tokenStr, ok := ctx.Value("token").(string)
+	if !ok || tokenStr == "" {
+		utils.Log.Error("Token not found or empty in context")
+		return actor{}, constants.ErrUnauthorized
+	}
+
+	userIDStr, err := jwtService.GetUserIDByToken(tokenStr)
+	if err != nil {
+		utils.Log.WithError(err).Error("Failed to get user ID from token")
+		return actor{}, constants.ErrUnauthorized
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		utils.Log.WithError(err).WithField("userIDStr", userIDStr).Error("Failed to parse user ID")
+		return actor{}, constants.ErrInvalidUUID
+	}
+
+	role, err := jwtService.GetRoleByToken(tokenStr)
+	if err != nil {
+		utils.Log.WithError(err).Error("Failed to get role from token")
+		return actor{}, constants.ErrUnauthorized
+	}
+
+	return actor{UserID: userID, Role: role}, nil
+}
+
+func (a actor) isAdmin() bool {
+	return a.Role == constants.ENUM_ROLE_ADMIN
+}
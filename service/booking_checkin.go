@@ -0,0 +1,38 @@
+package service
+
+import (
+	"fieldreserve/helpers"
+	"fieldreserve/utils"
+	"os"
+	"time"
+)
+
+// noShowSweepStart is the earliest start time the no-show sweep looks at. It reaches back
+// NO_SHOW_LOOKBACK_HOURS, enough to catch up after the worker was down, but never before
+// CHECKIN_ENABLED_AT, so bookings made before check-in existed are not marked as no-shows.
+func noShowSweepStart(closedBefore time.Time) time.Time {
+	start := closedBefore.Add(-time.Duration(helpers.GetEnvInt("NO_SHOW_LOOKBACK_HOURS", 72)) * time.Hour)
+
+	if value := os.Getenv("CHECKIN_ENABLED_AT"); value != "" {
+		enabledAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			utils.Log.WithError(err).WithField("value", value).Warn("Invalid CHECKIN_ENABLED_AT, ignoring it")
+		} else if enabledAt.After(start) {
+			start = enabledAt
+		}
+	}
+
+	return start
+}
+
+// checkInWindow is when a booking can be checked in: from CHECKIN_OPENS_MINUTES_BEFORE
+// its start until CHECKIN_CLOSES_MINUTES_AFTER it. Bookings not checked in by then are
+// marked as no-shows.
+func checkInWindow(startTime time.Time) (time.Time, time.Time) {
+	opens := startTime.Add(-time.Duration(helpers.GetEnvInt("CHECKIN_OPENS_MINUTES_BEFORE", 60)) * time.Minute)
+	return opens, startTime.Add(checkInGracePeriod())
+}
+
+func checkInGracePeriod() time.Duration {
+	return time.Duration(helpers.GetEnvInt("CHECKIN_CLOSES_MINUTES_AFTER", 30)) * time.Minute
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/migrations"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/revocation"
+	"fieldreserve/storage"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// concurrentBookings is how many requests race for the same slot in each test.
+const concurrentBookings = 20
+
+// openTestDB connects to the database named by TEST_DATABASE_DSN and migrates it. The
+// overlap guarantees live in Postgres, so these tests are skipped without one.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  dsn,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect postgres: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(concurrentBookings + 5)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := migrations.Migrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return db
+}
+
+// seedBookableField creates a user and a field that is open all day, and returns a
+// context authenticated as that user.
+func seedBookableField(t *testing.T, db *gorm.DB, jwtService InterfaceJWTService, bookingDate time.Time) (context.Context, model.Field) {
+	t.Helper()
+
+	verifiedAt := time.Now()
+	user := model.User{
+		UserID:          uuid.New(),
+		Name:            "Concurrency Test",
+		Email:           uuid.NewString() + "@example.com",
+		Password:        "password",
+		Role:            constants.ENUM_ROLE_USER,
+		EmailVerifiedAt: &verifiedAt,
+	}
+	category := model.Category{CategoryID: uuid.New(), Name: "Concurrency Test"}
+	field := model.Field{
+		FieldID:    uuid.New(),
+		CategoryID: category.CategoryID,
+		FieldName:  "Concurrency Test",
+		FieldPrice: 100000,
+	}
+	loc := helpers.GetAppLocation()
+	schedule := model.Schedule{
+		ScheduleID: uuid.New(),
+		FieldID:    field.FieldID,
+		DayOfWeek:  int(bookingDate.Weekday()),
+		OpenTime:   time.Date(2000, 1, 1, 0, 0, 0, 0, loc),
+		CloseTime:  time.Date(2000, 1, 1, 23, 59, 0, 0, loc),
+	}
+
+	for _, row := range []interface{}{&user, &category, &field, &schedule} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("seed %T: %v", row, err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Unscoped().Where("booking_id IN (?)", db.Model(&model.Booking{}).Select("booking_id").Where("field_id = ?", field.FieldID)).Delete(&model.BookingPriceItem{})
+		db.Unscoped().Where("booking_id IN (?)", db.Model(&model.Booking{}).Select("booking_id").Where("field_id = ?", field.FieldID)).Delete(&model.BookingStatusHistory{})
+		db.Unscoped().Where("field_id = ?", field.FieldID).Delete(&model.Booking{})
+		db.Unscoped().Delete(&schedule)
+		db.Unscoped().Delete(&field)
+		db.Unscoped().Delete(&category)
+		db.Unscoped().Delete(&user)
+	})
+
+	token, _, err := jwtService.GenerateToken(user.UserID.String(), user.Role, uuid.NewString())
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	return context.WithValue(context.Background(), "token", token), field
+}
+
+func newTestBookingService(db *gorm.DB, bookingRepo repository.IBookingRepository, jwtService InterfaceJWTService, t *testing.T) *BookingService {
+	fieldRepo := repository.NewFieldRepository(db)
+	scheduleRepo := repository.NewScheduleRepository(db)
+	notificationService := NewLogNotificationService()
+	waitlistService := NewWaitlistService(repository.NewWaitlistRepository(db), bookingRepo, fieldRepo, scheduleRepo, jwtService, notificationService)
+	voucherService := NewVoucherService(repository.NewVoucherRepository(db), fieldRepo, repository.NewCategoryRepository(db))
+
+	return NewBookingService(
+		bookingRepo,
+		jwtService,
+		scheduleRepo,
+		fieldRepo,
+		repository.NewUserRepository(db),
+		NewPricingService(repository.NewPricingRuleRepository(db)),
+		waitlistService,
+		voucherService,
+		notificationService,
+		storage.NewLocalStorage(t.TempDir()),
+	)
+}
+
+// raceCreateBooking fires concurrentBookings CreateBooking calls for the same slot at once
+// and checks that exactly one wins and every other caller gets ErrBookingOverlap.
+func raceCreateBooking(t *testing.T, ctx context.Context, bs *BookingService, field model.Field, bookingDate time.Time) {
+	t.Helper()
+
+	req := dto.CreateBookingRequest{
+		FieldID:       field.FieldID.String(),
+		BookingDate:   bookingDate.Format("2006-01-02"),
+		StartTime:     "10:00",
+		EndTime:       "11:00",
+		PaymentMethod: "transfer",
+	}
+
+	start := make(chan struct{})
+	errs := make([]error, concurrentBookings)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentBookings; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = bs.CreateBooking(ctx, req)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	created := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, constants.ErrBookingOverlap):
+		default:
+			t.Errorf("request %d: got %v, want nil or ErrBookingOverlap", i, err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("created %d bookings for one slot, want exactly 1", created)
+	}
+}
+
+func TestCreateBookingConcurrentSameSlot(t *testing.T) {
+	db := openTestDB(t)
+	jwtService := NewJWTService(revocation.NewMemoryStore())
+	bookingDate := time.Now().In(helpers.GetAppLocation()).AddDate(0, 0, 7)
+	ctx, field := seedBookableField(t, db, jwtService, bookingDate)
+
+	bs := newTestBookingService(db, repository.NewBookingRepository(db), jwtService, t)
+	raceCreateBooking(t, ctx, bs, field, bookingDate)
+}
+
+// unguardedBookingRepository skips the advisory lock and the overlap query, leaving the
+// bookings_no_overlap constraint as the only thing between racing inserts.
+type unguardedBookingRepository struct {
+	*repository.BookingRepository
+}
+
+func (unguardedBookingRepository) LockFieldForBooking(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID) error {
+	return nil
+}
+
+func (unguardedBookingRepository) CheckBookingOverlap(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, bookingDate time.Time, startTime, endTime time.Time, excludeBookingID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func TestCreateBookingConcurrentSameSlotConstraint(t *testing.T) {
+	db := openTestDB(t)
+	jwtService := NewJWTService(revocation.NewMemoryStore())
+	bookingDate := time.Now().In(helpers.GetAppLocation()).AddDate(0, 0, 7)
+	ctx, field := seedBookableField(t, db, jwtService, bookingDate)
+
+	bs := newTestBookingService(db, unguardedBookingRepository{repository.NewBookingRepository(db)}, jwtService, t)
+	raceCreateBooking(t, ctx, bs, field, bookingDate)
+}
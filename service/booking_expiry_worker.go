@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/helpers"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type BookingExpiryWorker struct {
+	bookingRepo     repository.IBookingRepository
+	waitlistService IWaitlistService
+	interval        time.Duration
+	verificationSLA time.Duration
+}
+
+// expiryBatchSize bounds how many bookings are locked by a single transaction.
+const expiryBatchSize = 100
+
+func NewBookingExpiryWorker(bookingRepo repository.IBookingRepository, waitlistService IWaitlistService) *BookingExpiryWorker {
+	return &BookingExpiryWorker{
+		bookingRepo:     bookingRepo,
+		waitlistService: waitlistService,
+		interval:        time.Duration(helpers.GetEnvInt("BOOKING_EXPIRY_INTERVAL_SECONDS", 60)) * time.Second,
+		verificationSLA: time.Duration(helpers.GetEnvInt("BOOKING_VERIFICATION_SLA_MINUTES", 720)) * time.Minute,
+	}
+}
+
+// Start runs the worker until ctx is cancelled. It is meant to be started in its own goroutine.
+func (w *BookingExpiryWorker) Start(ctx context.Context) {
+	utils.Log.WithFields(logrus.Fields{
+		"interval":        w.interval,
+		"verificationSLA": w.verificationSLA,
+	}).Info("Booking expiry worker started")
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			utils.Log.Info("Booking expiry worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *BookingExpiryWorker) RunOnce(ctx context.Context) {
+	w.expirePendingBookings(ctx)
+	w.flagStaleVerifications(ctx)
+	w.markNoShows(ctx)
+	w.waitlistService.ExpireHolds(ctx)
+}
+
+// expirePendingBookings cancels unpaid bookings whose payment window has passed,
+// one locked batch at a time, and offers the released slots to the waitlist.
+func (w *BookingExpiryWorker) expirePendingBookings(ctx context.Context) {
+	for ctx.Err() == nil {
+		var expired []model.Booking
+
+		err := w.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+			now := time.Now().In(helpers.GetAppLocation())
+
+			bookings, err := w.bookingRepo.GetExpiredPendingBookings(ctx, tx, now, expiryBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, booking := range bookings {
+				if err := applyBookingTransition(ctx, tx, w.bookingRepo, &booking, constants.ENUM_STATUS_BOOKING_CALCEL, systemActor, constants.ENUM_CANCEL_REASON_PAYMENT_EXPIRED); err != nil {
+					return err
+				}
+
+				utils.Log.WithFields(logrus.Fields{
+					"bookingID":    booking.BookingID,
+					"paymentDueAt": booking.PaymentDueAt,
+				}).Info("Pending booking expired")
+			}
+
+			expired = bookings
+			return nil
+		})
+		if err != nil {
+			utils.Log.WithError(err).Error("Failed to expire pending bookings")
+			return
+		}
+
+		for _, booking := range expired {
+			w.waitlistService.ReleaseSlot(ctx, booking)
+		}
+
+		if len(expired) < expiryBatchSize {
+			return
+		}
+	}
+}
+
+// flagStaleVerifications flags bookings whose payment proof has waited for review longer
+// than the verification SLA, so admins can prioritise them.
+func (w *BookingExpiryWorker) flagStaleVerifications(ctx context.Context) {
+	bookings, err := w.bookingRepo.GetWaitingVerificationBookings(ctx, nil)
+	if err != nil {
+		utils.Log.WithError(err).Error("Failed to get bookings waiting for verification")
+		return
+	}
+
+	now := time.Now().In(helpers.GetAppLocation())
+	for _, booking := range bookings {
+		if booking.VerificationFlaggedAt != nil || booking.PaymentUploadedAt == nil {
+			continue
+		}
+		if now.Sub(*booking.PaymentUploadedAt) < w.verificationSLA {
+			continue
+		}
+
+		flagged, err := w.bookingRepo.FlagBookingForVerification(ctx, nil, booking.BookingID, now)
+		if err != nil {
+			utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to flag booking for verification")
+			continue
+		}
+		if flagged {
+			utils.Log.WithFields(logrus.Fields{
+				"bookingID":         booking.BookingID,
+				"paymentUploadedAt": booking.PaymentUploadedAt,
+			}).Warn("Booking payment proof has not been reviewed within the SLA")
+		}
+	}
+}
+
+// markNoShows moves confirmed bookings whose check-in window closed without a check-in to
+// no_show. Their slot has already passed, so nothing is offered to the waitlist.
+func (w *BookingExpiryWorker) markNoShows(ctx context.Context) {
+	for ctx.Err() == nil {
+		var missed []model.Booking
+
+		err := w.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+			closedBefore := time.Now().In(helpers.GetAppLocation()).Add(-checkInGracePeriod())
+
+			bookings, err := w.bookingRepo.GetMissedCheckInBookings(ctx, tx, closedBefore, noShowSweepStart(closedBefore), expiryBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, booking := range bookings {
+				if err := applyBookingTransition(ctx, tx, w.bookingRepo, &booking, constants.ENUM_STATUS_BOOKING_NO_SHOW, systemActor, constants.ENUM_NO_SHOW_REASON_MISSED_CHECK_IN); err != nil {
+					return err
+				}
+
+				utils.Log.WithFields(logrus.Fields{
+					"bookingID": booking.BookingID,
+					"startTime": booking.StartTime,
+				}).Info("Booking marked as no-show")
+			}
+
+			missed = bookings
+			return nil
+		})
+		if err != nil {
+			utils.Log.WithError(err).Error("Failed to mark no-show bookings")
+			return
+		}
+
+		if len(missed) < expiryBatchSize {
+			return
+		}
+	}
+}
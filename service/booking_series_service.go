@@ -0,0 +1,656 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type (
+	IBookingSeriesService interface {
+		CreateBookingSeries(ctx context.Context, req dto.CreateBookingSeriesRequest) (dto.BookingSeriesResultResponse, error)
+		GetBookingSeriesByID(ctx context.Context, seriesID string) (dto.BookingSeriesResponse, error)
+		UpdateBookingSeries(ctx context.Context, req dto.UpdateBookingSeriesRequest) (dto.BookingSeriesResultResponse, error)
+		UpdateSeriesOccurrence(ctx context.Context, req dto.UpdateSeriesOccurrenceRequest) (dto.BookingResponse, error)
+		CancelBookingSeries(ctx context.Context, req dto.CancelBookingSeriesRequest) (dto.BookingSeriesResultResponse, error)
+		CancelSeriesOccurrence(ctx context.Context, req dto.CancelSeriesOccurrenceRequest) (dto.BookingResponse, error)
+	}
+
+	BookingSeriesService struct {
+		seriesRepo      repository.IBookingSeriesRepository
+		bookingRepo     repository.IBookingRepository
+		jwtService      InterfaceJWTService
+		scheduleRepo    repository.IScheduleRepository
+		fieldRepo       repository.IFieldRepository
+		userRepo        repository.IUserRepository
+		pricingService  IPricingService
+		waitlistService IWaitlistService
+	}
+)
+
+// maxSeriesOccurrences caps how many bookings a single series may expand into,
+// roughly one season of weekly play.
+const maxSeriesOccurrences = 52
+
+func NewBookingSeriesService(
+	seriesRepo repository.IBookingSeriesRepository,
+	bookingRepo repository.IBookingRepository,
+	jwtService InterfaceJWTService,
+	scheduleRepo repository.IScheduleRepository,
+	fieldRepo repository.IFieldRepository,
+	userRepo repository.IUserRepository,
+	pricingService IPricingService,
+	waitlistService IWaitlistService,
+) *BookingSeriesService {
+	return &BookingSeriesService{
+		seriesRepo:      seriesRepo,
+		bookingRepo:     bookingRepo,
+		jwtService:      jwtService,
+		scheduleRepo:    scheduleRepo,
+		fieldRepo:       fieldRepo,
+		userRepo:        userRepo,
+		pricingService:  pricingService,
+		waitlistService: waitlistService,
+	}
+}
+
+// CreateBookingSeries expands the recurrence into individual bookings. Every occurrence
+// is validated and saved on its own, so one conflicting week does not block the rest.
+func (bss *BookingSeriesService) CreateBookingSeries(ctx context.Context, req dto.CreateBookingSeriesRequest) (dto.BookingSeriesResultResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"fieldID":       req.FieldID,
+		"startDate":     req.StartDate,
+		"startTime":     req.StartTime,
+		"endTime":       req.EndTime,
+		"intervalWeeks": req.IntervalWeeks,
+		"untilDate":     req.UntilDate,
+		"count":         req.Count,
+	}).Info("Starting booking series creation")
+
+	loc := helpers.GetAppLocation()
+
+	user, err := actorFromContext(ctx, bss.jwtService)
+	if err != nil {
+		return dto.BookingSeriesResultResponse{}, err
+	}
+
+	// A series holds many slots at once, so it needs the same verified address as a single booking.
+	if err := requireVerifiedEmail(ctx, bss.userRepo, user.UserID); err != nil {
+		return dto.BookingSeriesResultResponse{}, err
+	}
+
+	fieldID, err := uuid.Parse(req.FieldID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", req.FieldID).Error("Failed to parse field ID")
+		return dto.BookingSeriesResultResponse{}, constants.ErrInvalidUUID
+	}
+
+	startDate, startTime, endTime, err := parseBookingWindow(req.StartDate, req.StartTime, req.EndTime, loc)
+	if err != nil {
+		return dto.BookingSeriesResultResponse{}, err
+	}
+	if !endTime.After(startTime) {
+		utils.Log.Warn("Invalid time range for booking series")
+		return dto.BookingSeriesResultResponse{}, constants.ErrInvalidTimeRange
+	}
+
+	untilDate, err := parseOptionalDate(req.UntilDate)
+	if err != nil {
+		return dto.BookingSeriesResultResponse{}, err
+	}
+	if untilDate == nil && req.Count == 0 {
+		utils.Log.Warn("Booking series has neither until date nor count")
+		return dto.BookingSeriesResultResponse{}, constants.ErrSeriesEndRequired
+	}
+	if untilDate != nil && untilDate.Before(startDate) {
+		utils.Log.Warn("Booking series until date is before start date")
+		return dto.BookingSeriesResultResponse{}, constants.ErrInvalidDateRange
+	}
+
+	intervalWeeks := req.IntervalWeeks
+	if intervalWeeks == 0 {
+		intervalWeeks = 1
+	}
+
+	dates, err := expandSeriesDates(startDate, intervalWeeks, untilDate, req.Count)
+	if err != nil {
+		return dto.BookingSeriesResultResponse{}, err
+	}
+
+	series := model.BookingSeries{
+		SeriesID:        uuid.New(),
+		UserID:          user.UserID,
+		FieldID:         fieldID,
+		PaymentMethod:   req.PaymentMethod,
+		IntervalWeeks:   intervalWeeks,
+		DayOfWeek:       int(startDate.Weekday()),
+		StartTime:       startTime.Format("15:04"),
+		EndTime:         endTime.Format("15:04"),
+		StartDate:       startDate,
+		UntilDate:       untilDate,
+		OccurrenceCount: req.Count,
+		Status:          constants.ENUM_BOOKING_SERIES_ACTIVE,
+	}
+
+	if err := bss.seriesRepo.CreateBookingSeries(ctx, nil, series); err != nil {
+		utils.Log.WithError(err).Error("Failed to create booking series")
+		return dto.BookingSeriesResultResponse{}, constants.ErrCreateBookingSeries
+	}
+
+	result := dto.BookingSeriesResultResponse{
+		Succeeded: []dto.SeriesOccurrenceResult{},
+		Conflicts: []dto.SeriesOccurrenceResult{},
+	}
+
+	for _, date := range dates {
+		dateStr := date.Format("2006-01-02")
+
+		booking, err := bss.createOccurrence(ctx, series, dateStr, user)
+		if err != nil {
+			utils.Log.WithError(err).WithFields(logrus.Fields{
+				"seriesID":    series.SeriesID,
+				"bookingDate": dateStr,
+			}).Warn("Skipping booking series occurrence")
+			result.Conflicts = append(result.Conflicts, dto.SeriesOccurrenceResult{
+				BookingDate: dateStr,
+				Reason:      err.Error(),
+			})
+			continue
+		}
+
+		series.Bookings = append(series.Bookings, booking)
+		result.Succeeded = append(result.Succeeded, toSeriesOccurrenceResult(booking))
+	}
+
+	// A series without a single booking has nothing left to manage.
+	if len(result.Succeeded) == 0 {
+		now := time.Now().In(loc)
+		series.Status = constants.ENUM_BOOKING_SERIES_CANCELLED
+		series.CancelledAt = &now
+		if err := bss.seriesRepo.UpdateBookingSeries(ctx, nil, series); err != nil {
+			utils.Log.WithError(err).WithField("seriesID", series.SeriesID).Error("Failed to close empty booking series")
+		}
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"seriesID":  series.SeriesID,
+		"succeeded": len(result.Succeeded),
+		"conflicts": len(result.Conflicts),
+	}).Info("Booking series created")
+
+	result.Series = toBookingSeriesResponse(series)
+	return result, nil
+}
+
+func (bss *BookingSeriesService) GetBookingSeriesByID(ctx context.Context, seriesID string) (dto.BookingSeriesResponse, error) {
+	utils.Log.WithField("seriesID", seriesID).Info("Fetching booking series by ID")
+
+	series, _, err := bss.getAccessibleSeries(ctx, seriesID)
+	if err != nil {
+		return dto.BookingSeriesResponse{}, err
+	}
+
+	return toBookingSeriesResponse(series), nil
+}
+
+// UpdateBookingSeries moves every upcoming occurrence to the new time on its own date.
+// Occurrences that cannot be moved keep their current time and are reported as conflicts.
+func (bss *BookingSeriesService) UpdateBookingSeries(ctx context.Context, req dto.UpdateBookingSeriesRequest) (dto.BookingSeriesResultResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"seriesID":  req.SeriesID,
+		"startTime": req.StartTime,
+		"endTime":   req.EndTime,
+	}).Info("Updating booking series")
+
+	series, _, err := bss.getAccessibleSeries(ctx, req.SeriesID)
+	if err != nil {
+		return dto.BookingSeriesResultResponse{}, err
+	}
+	if series.Status == constants.ENUM_BOOKING_SERIES_CANCELLED {
+		return dto.BookingSeriesResultResponse{}, constants.ErrBookingSeriesCancelled
+	}
+
+	loc := helpers.GetAppLocation()
+	_, startTime, endTime, err := parseBookingWindow(series.StartDate.Format("2006-01-02"), req.StartTime, req.EndTime, loc)
+	if err != nil {
+		return dto.BookingSeriesResultResponse{}, err
+	}
+	if !endTime.After(startTime) {
+		utils.Log.Warn("Invalid time range for booking series")
+		return dto.BookingSeriesResultResponse{}, constants.ErrInvalidTimeRange
+	}
+
+	result := dto.BookingSeriesResultResponse{
+		Succeeded: []dto.SeriesOccurrenceResult{},
+		Conflicts: []dto.SeriesOccurrenceResult{},
+	}
+
+	now := time.Now().In(loc)
+	for i, booking := range series.Bookings {
+		if !isBookingActive(booking.Status) || !booking.StartTime.After(now) {
+			continue
+		}
+
+		dateStr := booking.BookingDate.In(loc).Format("2006-01-02")
+		moved, err := bss.moveOccurrence(ctx, booking, dateStr, req.StartTime, req.EndTime)
+		if err != nil {
+			utils.Log.WithError(err).WithFields(logrus.Fields{
+				"seriesID":  series.SeriesID,
+				"bookingID": booking.BookingID,
+			}).Warn("Could not move booking series occurrence")
+			result.Conflicts = append(result.Conflicts, dto.SeriesOccurrenceResult{
+				BookingDate: dateStr,
+				BookingID:   &series.Bookings[i].BookingID,
+				Reason:      err.Error(),
+			})
+			continue
+		}
+
+		series.Bookings[i] = moved
+		result.Succeeded = append(result.Succeeded, toSeriesOccurrenceResult(moved))
+	}
+
+	series.StartTime = startTime.Format("15:04")
+	series.EndTime = endTime.Format("15:04")
+	if err := bss.seriesRepo.UpdateBookingSeries(ctx, nil, series); err != nil {
+		utils.Log.WithError(err).WithField("seriesID", series.SeriesID).Error("Failed to update booking series")
+		return dto.BookingSeriesResultResponse{}, constants.ErrUpdateBookingSeries
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"seriesID":  series.SeriesID,
+		"succeeded": len(result.Succeeded),
+		"conflicts": len(result.Conflicts),
+	}).Info("Booking series updated")
+
+	result.Series = toBookingSeriesResponse(series)
+	return result, nil
+}
+
+func (bss *BookingSeriesService) UpdateSeriesOccurrence(ctx context.Context, req dto.UpdateSeriesOccurrenceRequest) (dto.BookingResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"seriesID":    req.SeriesID,
+		"bookingID":   req.BookingID,
+		"bookingDate": req.BookingDate,
+		"startTime":   req.StartTime,
+		"endTime":     req.EndTime,
+	}).Info("Updating booking series occurrence")
+
+	series, _, err := bss.getAccessibleSeries(ctx, req.SeriesID)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	booking, err := findSeriesOccurrence(series, req.BookingID)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	bookingDate := req.BookingDate
+	if bookingDate == "" {
+		bookingDate = booking.BookingDate.In(helpers.GetAppLocation()).Format("2006-01-02")
+	}
+
+	moved, err := bss.moveOccurrence(ctx, booking, bookingDate, req.StartTime, req.EndTime)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	utils.Log.WithField("bookingID", moved.BookingID).Info("Booking series occurrence updated")
+
+	return toBookingResponse(moved), nil
+}
+
+// CancelBookingSeries cancels every upcoming occurrence it can. Occurrences that cannot be
+// cancelled are returned as conflicts and keep the series active, so the cancellation can
+// be retried; the series is closed once none are left. Refunds for paid occurrences
+// follow the cancellation policy.
+func (bss *BookingSeriesService) CancelBookingSeries(ctx context.Context, req dto.CancelBookingSeriesRequest) (dto.BookingSeriesResultResponse, error) {
+	utils.Log.WithField("seriesID", req.SeriesID).Info("Cancelling booking series")
+
+	series, user, err := bss.getAccessibleSeries(ctx, req.SeriesID)
+	if err != nil {
+		return dto.BookingSeriesResultResponse{}, err
+	}
+	if series.Status == constants.ENUM_BOOKING_SERIES_CANCELLED {
+		return dto.BookingSeriesResultResponse{}, constants.ErrBookingSeriesCancelled
+	}
+
+	loc := helpers.GetAppLocation()
+	now := time.Now().In(loc)
+
+	result := dto.BookingSeriesResultResponse{
+		Succeeded: []dto.SeriesOccurrenceResult{},
+		Conflicts: []dto.SeriesOccurrenceResult{},
+	}
+
+	// Each occurrence is cancelled on its own, so one that can't be cancelled is reported
+	// and kept while the rest go ahead.
+	for i, booking := range series.Bookings {
+		if !canTransitionBooking(booking.Status, constants.ENUM_STATUS_BOOKING_CALCEL) || !booking.StartTime.After(now) {
+			continue
+		}
+
+		err := bss.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+			return cancelBookingWithRefund(ctx, tx, bss.bookingRepo, &booking, user, constants.ENUM_CANCEL_REASON_SERIES_CANCELLED, now)
+		})
+		if err != nil {
+			utils.Log.WithError(err).WithFields(logrus.Fields{
+				"seriesID":  series.SeriesID,
+				"bookingID": booking.BookingID,
+			}).Warn("Could not cancel booking series occurrence")
+			conflict := toSeriesOccurrenceResult(series.Bookings[i])
+			conflict.Reason = err.Error()
+			result.Conflicts = append(result.Conflicts, conflict)
+			continue
+		}
+
+		series.Bookings[i] = booking
+		result.Succeeded = append(result.Succeeded, toSeriesOccurrenceResult(booking))
+		bss.waitlistService.ReleaseSlot(ctx, booking)
+	}
+
+	if len(result.Conflicts) == 0 {
+		series.Status = constants.ENUM_BOOKING_SERIES_CANCELLED
+		series.CancelledAt = &now
+		if err := bss.seriesRepo.UpdateBookingSeries(ctx, nil, series); err != nil {
+			utils.Log.WithError(err).WithField("seriesID", series.SeriesID).Error("Failed to cancel booking series")
+			return dto.BookingSeriesResultResponse{}, constants.ErrCancelBookingSeries
+		}
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"seriesID":  series.SeriesID,
+		"status":    series.Status,
+		"cancelled": len(result.Succeeded),
+		"kept":      len(result.Conflicts),
+	}).Info("Booking series cancelled")
+
+	result.Series = toBookingSeriesResponse(series)
+	return result, nil
+}
+
+func (bss *BookingSeriesService) CancelSeriesOccurrence(ctx context.Context, req dto.CancelSeriesOccurrenceRequest) (dto.BookingResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"seriesID":  req.SeriesID,
+		"bookingID": req.BookingID,
+	}).Info("Cancelling booking series occurrence")
+
+	series, user, err := bss.getAccessibleSeries(ctx, req.SeriesID)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	booking, err := findSeriesOccurrence(series, req.BookingID)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	now := time.Now().In(helpers.GetAppLocation())
+	err = bss.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		return cancelBookingWithRefund(ctx, tx, bss.bookingRepo, &booking, user, constants.ENUM_CANCEL_REASON_USER_REQUEST, now)
+	})
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	bss.waitlistService.ReleaseSlot(ctx, booking)
+
+	utils.Log.WithField("bookingID", booking.BookingID).Info("Booking series occurrence cancelled")
+
+	return toBookingResponse(booking), nil
+}
+
+func (bss *BookingSeriesService) getAccessibleSeries(ctx context.Context, seriesID string) (model.BookingSeries, actor, error) {
+	user, err := actorFromContext(ctx, bss.jwtService)
+	if err != nil {
+		return model.BookingSeries{}, actor{}, err
+	}
+
+	if _, err := uuid.Parse(seriesID); err != nil {
+		utils.Log.WithError(err).WithField("seriesID", seriesID).Error("Invalid booking series ID format")
+		return model.BookingSeries{}, actor{}, constants.ErrInvalidUUID
+	}
+
+	series, _, err := bss.seriesRepo.GetBookingSeriesByID(ctx, nil, seriesID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("seriesID", seriesID).Error("Booking series not found")
+		return model.BookingSeries{}, actor{}, constants.ErrBookingSeriesNotFound
+	}
+
+	if err := authorize(user, series.UserID, ownerOrAdmin, "access booking series", logrus.Fields{"seriesID": seriesID}); err != nil {
+		return model.BookingSeries{}, actor{}, err
+	}
+
+	return series, user, nil
+}
+
+func (bss *BookingSeriesService) createOccurrence(ctx context.Context, series model.BookingSeries, bookingDateStr string, by actor) (model.Booking, error) {
+	loc := helpers.GetAppLocation()
+
+	bookingDate, startTime, endTime, err := parseBookingWindow(bookingDateStr, series.StartTime, series.EndTime, loc)
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	field, err := validateBookingSlot(ctx, bss.fieldRepo, bss.scheduleRepo, series.FieldID.String(), bookingDate, startTime, endTime)
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	price, err := bss.pricingService.CalculatePrice(ctx, PriceParams{
+		Field:     field,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", series.FieldID).Error("Failed to calculate booking price")
+		return model.Booking{}, constants.ErrCalculatePrice
+	}
+
+	bookingID := uuid.New()
+	seriesID := series.SeriesID
+	booking := model.Booking{
+		BookingID:     bookingID,
+		UserID:        series.UserID,
+		FieldID:       series.FieldID,
+		SeriesID:      &seriesID,
+		PaymentMethod: series.PaymentMethod,
+		BookingDate:   bookingDate,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		TotalPayment:  price.Total,
+		Status:        constants.ENUM_STATUS_BOOKING_PENDING,
+		PaymentDueAt:  seriesPaymentDeadline(startTime),
+		PriceItems:    toBookingPriceItems(bookingID, price.Items),
+	}
+
+	err = reserveBookingSlot(ctx, bss.bookingRepo, bss.waitlistService, booking, func(tx *gorm.DB) error {
+		if err := bss.bookingRepo.CreateBooking(ctx, tx, booking); err != nil {
+			if repository.IsExclusionViolation(err) {
+				return err
+			}
+			utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to create booking in database")
+			return constants.ErrCreateBooking
+		}
+		return recordBookingStatus(ctx, tx, bss.bookingRepo, booking, "", by, "", time.Now().In(loc))
+	})
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// moveOccurrence reprices an unpaid occurrence for its new slot and saves it together
+// with the new price breakdown.
+func (bss *BookingSeriesService) moveOccurrence(ctx context.Context, booking model.Booking, bookingDateStr, startTimeStr, endTimeStr string) (model.Booking, error) {
+	if booking.Status != constants.ENUM_STATUS_BOOKING_PENDING && booking.Status != constants.ENUM_STATUS_BOOKING_WAITING {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": booking.BookingID,
+			"status":    booking.Status,
+		}).Warn("Cannot move booking series occurrence - booking already final")
+		return model.Booking{}, constants.ErrBookingAlreadyFinal
+	}
+
+	bookingDate, startTime, endTime, err := parseBookingWindow(bookingDateStr, startTimeStr, endTimeStr, helpers.GetAppLocation())
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	field, err := validateBookingSlot(ctx, bss.fieldRepo, bss.scheduleRepo, booking.FieldID.String(), bookingDate, startTime, endTime)
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	price, err := bss.pricingService.CalculatePrice(ctx, PriceParams{
+		Field:     field,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", booking.FieldID).Error("Failed to calculate booking price")
+		return model.Booking{}, constants.ErrCalculatePrice
+	}
+
+	previous := booking
+	// An uploaded proof is checked against what the customer paid, so a new price is
+	// settled through the payment adjustment, as with a reschedule. An unpaid occurrence
+	// simply takes the new price and a deadline that falls before its new start.
+	if booking.Status == constants.ENUM_STATUS_BOOKING_PENDING {
+		booking.PaymentDueAt = seriesPaymentDeadline(startTime)
+	} else {
+		booking.PaymentAdjustment = roundPrice(booking.PaymentAdjustment + price.Total - booking.TotalPayment)
+	}
+	booking.BookingDate = bookingDate
+	booking.StartTime = startTime
+	booking.EndTime = endTime
+	booking.TotalPayment = price.Total
+	booking.PriceItems = nil
+
+	priceItems := toBookingPriceItems(booking.BookingID, price.Items)
+	err = reserveBookingSlot(ctx, bss.bookingRepo, bss.waitlistService, booking, func(tx *gorm.DB) error {
+		if err := bss.bookingRepo.RescheduleBooking(ctx, tx, booking); err != nil {
+			if repository.IsExclusionViolation(err) {
+				return err
+			}
+			utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to update booking in database")
+			return constants.ErrUpdateBooking
+		}
+		if err := bss.bookingRepo.ReplaceBookingPriceItems(ctx, tx, booking.BookingID, priceItems); err != nil {
+			utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to replace booking price items")
+			return constants.ErrUpdateBooking
+		}
+		return nil
+	})
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	bss.waitlistService.ReleaseSlot(ctx, previous)
+
+	booking.PriceItems = priceItems
+	return booking, nil
+}
+
+// expandSeriesDates lists the dates of a weekly recurrence starting at start. The
+// recurrence stops at until or after count dates, whichever comes first.
+func expandSeriesDates(start time.Time, intervalWeeks int, until *time.Time, count int) ([]time.Time, error) {
+	if count > maxSeriesOccurrences {
+		utils.Log.WithField("count", count).Warn("Booking series count exceeds maximum")
+		return nil, constants.ErrSeriesTooManyOccurrences
+	}
+
+	var dates []time.Time
+	for date := start; ; date = date.AddDate(0, 0, 7*intervalWeeks) {
+		if until != nil && date.After(*until) {
+			break
+		}
+		if count > 0 && len(dates) == count {
+			break
+		}
+		if len(dates) == maxSeriesOccurrences {
+			utils.Log.WithField("start", start).Warn("Booking series until date exceeds maximum occurrences")
+			return nil, constants.ErrSeriesTooManyOccurrences
+		}
+
+		dates = append(dates, date)
+	}
+
+	return dates, nil
+}
+
+// seriesPaymentDeadline gives each occurrence until shortly before it starts to be paid,
+// instead of the short checkout window used for one-off bookings.
+func seriesPaymentDeadline(startTime time.Time) *time.Time {
+	deadline := startTime.Add(-bookingMinLeadTime)
+	return &deadline
+}
+
+func findSeriesOccurrence(series model.BookingSeries, bookingID string) (model.Booking, error) {
+	if _, err := uuid.Parse(bookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Invalid booking ID format")
+		return model.Booking{}, constants.ErrInvalidUUID
+	}
+
+	for _, booking := range series.Bookings {
+		if booking.BookingID.String() != bookingID {
+			continue
+		}
+		if !isBookingActive(booking.Status) {
+			return model.Booking{}, constants.ErrBookingAlreadyFinal
+		}
+		return booking, nil
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"seriesID":  series.SeriesID,
+		"bookingID": bookingID,
+	}).Warn("Booking is not an occurrence of the series")
+	return model.Booking{}, constants.ErrOccurrenceNotInSeries
+}
+
+func toSeriesOccurrenceResult(booking model.Booking) dto.SeriesOccurrenceResult {
+	bookingID := booking.BookingID
+	return dto.SeriesOccurrenceResult{
+		BookingDate:  booking.BookingDate.In(helpers.GetAppLocation()).Format("2006-01-02"),
+		BookingID:    &bookingID,
+		TotalPayment: booking.TotalPayment,
+	}
+}
+
+func toBookingSeriesResponse(series model.BookingSeries) dto.BookingSeriesResponse {
+	res := dto.BookingSeriesResponse{
+		SeriesID:        series.SeriesID,
+		UserID:          series.UserID,
+		FieldID:         series.FieldID,
+		PaymentMethod:   series.PaymentMethod,
+		IntervalWeeks:   series.IntervalWeeks,
+		DayOfWeek:       series.DayOfWeek,
+		DayName:         helpers.DayIntToName(series.DayOfWeek),
+		StartTime:       series.StartTime,
+		EndTime:         series.EndTime,
+		StartDate:       series.StartDate.Format("2006-01-02"),
+		OccurrenceCount: series.OccurrenceCount,
+		Status:          series.Status,
+		CancelledAt:     series.CancelledAt,
+	}
+
+	if series.UntilDate != nil {
+		res.UntilDate = series.UntilDate.Format("2006-01-02")
+	}
+	for _, booking := range series.Bookings {
+		res.Bookings = append(res.Bookings, toBookingResponse(booking))
+	}
+
+	return res
+}
@@ -2,18 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fieldreserve/constants"
 	"fieldreserve/dto"
 	"fieldreserve/helpers"
 	"fieldreserve/model"
 	"fieldreserve/repository"
+	"fieldreserve/storage"
 	"fieldreserve/utils"
+	"fmt"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/skip2/go-qrcode"
+	"gorm.io/gorm"
 )
 
 type (
@@ -22,30 +27,67 @@ type (
 		GetAllBooking(ctx context.Context, req dto.BookingPaginationRequest) (dto.BookingPaginationResponse, error)
 		GetUserBookingHistory(ctx context.Context, req dto.BookingPaginationRequest) (dto.BookingPaginationResponse, error)
 		GetBookingByID(ctx context.Context, bookingID string) (dto.BookingFullResponse, error)
+		GetBookingTimeline(ctx context.Context, bookingID string) (dto.BookingTimelineResponse, error)
+		QuoteBooking(ctx context.Context, req dto.QuoteBookingRequest) (dto.QuoteBookingResponse, error)
 		UpdateBookingStatus(ctx context.Context, req dto.UpdateBookingStatusRequest) (dto.BookingResponse, error)
+		CancelBooking(ctx context.Context, req dto.CancelBookingRequest) (dto.BookingResponse, error)
+		RescheduleBooking(ctx context.Context, req dto.RescheduleBookingRequest) (dto.RescheduleBookingResponse, error)
 		DeleteBooking(ctx context.Context, req dto.DeleteBookingRequest) (dto.BookingResponse, error)
+		UploadPaymentProof(ctx context.Context, req dto.UploadPaymentProofRequest) (dto.BookingResponse, error)
+		RejectPaymentProof(ctx context.Context, req dto.RejectPaymentProofRequest) (dto.BookingResponse, error)
+		GetVerificationQueue(ctx context.Context) ([]dto.VerificationQueueItem, error)
+		GetPaymentProof(ctx context.Context, bookingID, proofID string) (storage.Object, error)
+		GetPaymentProofURL(ctx context.Context, bookingID, proofID string) (dto.SignedURLResponse, error)
+		GetPaymentQR(ctx context.Context, bookingID string) ([]byte, error)
+		GenerateInvoice(ctx context.Context, bookingID string) ([]byte, error)
+		CheckInBooking(ctx context.Context, req dto.CheckInRequest) (dto.CheckInResponse, error)
 	}
 
 	BookingService struct {
-		bookingRepo  repository.IBookingRepository
-		jwtService   InterfaceJWTService
-		scheduleRepo repository.IScheduleRepository
-		fieldRepo    repository.IFieldRepository
+		bookingRepo     repository.IBookingRepository
+		jwtService      InterfaceJWTService
+		scheduleRepo    repository.IScheduleRepository
+		fieldRepo       repository.IFieldRepository
+		userRepo        repository.IUserRepository
+		pricingService  IPricingService
+		waitlistService IWaitlistService
+		voucherService  IVoucherService
+
+		notificationService INotificationService
+		store               storage.Storage
 	}
 )
 
+const (
+	// bookingMinLeadTime is the minimum notice required between now and the start of a booking.
+	bookingMinLeadTime = 2 * time.Hour
+)
+
 func NewBookingService(
 	bookingRepo repository.IBookingRepository,
 	jwtService InterfaceJWTService,
 	scheduleRepo repository.IScheduleRepository,
 	fieldRepo repository.IFieldRepository,
+	userRepo repository.IUserRepository,
+	pricingService IPricingService,
+	waitlistService IWaitlistService,
+	voucherService IVoucherService,
+	notificationService INotificationService,
+	store storage.Storage,
 ) *BookingService {
 	utils.Log.Info("Initializing new BookingService")
 	return &BookingService{
-		bookingRepo:  bookingRepo,
-		jwtService:   jwtService,
-		scheduleRepo: scheduleRepo,
-		fieldRepo:    fieldRepo,
+		bookingRepo:     bookingRepo,
+		jwtService:      jwtService,
+		scheduleRepo:    scheduleRepo,
+		fieldRepo:       fieldRepo,
+		userRepo:        userRepo,
+		pricingService:  pricingService,
+		waitlistService: waitlistService,
+		voucherService:  voucherService,
+
+		notificationService: notificationService,
+		store:               store,
 	}
 }
 
@@ -63,26 +105,18 @@ func (bs *BookingService) CreateBooking(ctx context.Context, req dto.CreateBooki
 
 	// === [1] Extract Token & User ID ===
 	utils.Log.Debug("Extracting token and user ID from context")
-	tokenStr, ok := ctx.Value("token").(string)
-	if !ok || tokenStr == "" {
-		utils.Log.Error("Token not found or empty in context")
-		return dto.BookingResponse{}, constants.ErrUnauthorized
-	}
-
-	userIDStr, err := bs.jwtService.GetUserIDByToken(tokenStr)
+	user, err := actorFromContext(ctx, bs.jwtService)
 	if err != nil {
-		utils.Log.WithError(err).Error("Failed to get user ID from token")
-		return dto.BookingResponse{}, constants.ErrUnauthorized
-	}
-
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		utils.Log.WithError(err).WithField("userIDStr", userIDStr).Error("Failed to parse user ID")
-		return dto.BookingResponse{}, constants.ErrInvalidUUID
+		return dto.BookingResponse{}, err
 	}
+	userID := user.UserID
 
 	utils.Log.WithField("userID", userID).Info("Successfully extracted user ID from token")
 
+	if err := requireVerifiedEmail(ctx, bs.userRepo, userID); err != nil {
+		return dto.BookingResponse{}, err
+	}
+
 	// === [2] Parse Field ID ===
 	utils.Log.WithField("fieldID", req.FieldID).Debug("Parsing field ID")
 	fieldID, err := uuid.Parse(req.FieldID)
@@ -92,148 +126,66 @@ func (bs *BookingService) CreateBooking(ctx context.Context, req dto.CreateBooki
 	}
 
 	// === [3] Parse Booking Date & Time ===
-	utils.Log.WithFields(logrus.Fields{
-		"bookingDate": req.BookingDate,
-		"startTime":   req.StartTime,
-		"endTime":     req.EndTime,
-	}).Debug("Parsing booking date and time")
-
-	bookingDate, err := time.ParseInLocation("2006-01-02", req.BookingDate, loc)
+	bookingDate, startTime, endTime, err := parseBookingWindow(req.BookingDate, req.StartTime, req.EndTime, loc)
 	if err != nil {
-		utils.Log.WithError(err).WithField("bookingDate", req.BookingDate).Error("Failed to parse booking date")
-		return dto.BookingResponse{}, constants.ErrInvalidBookingDate
+		return dto.BookingResponse{}, err
 	}
 
-	startTimeParsed, err := time.ParseInLocation("15:04", req.StartTime, loc)
+	// === [4] Validasi Waktu, Field & Jadwal ===
+	field, err := validateBookingSlot(ctx, bs.fieldRepo, bs.scheduleRepo, req.FieldID, bookingDate, startTime, endTime)
 	if err != nil {
-		utils.Log.WithError(err).WithField("startTime", req.StartTime).Error("Failed to parse start time")
-		return dto.BookingResponse{}, constants.ErrInvalidTimeFormat
+		return dto.BookingResponse{}, err
 	}
-	endTimeParsed, err := time.ParseInLocation("15:04", req.EndTime, loc)
-	if err != nil {
-		utils.Log.WithError(err).WithField("endTime", req.EndTime).Error("Failed to parse end time")
-		return dto.BookingResponse{}, constants.ErrInvalidTimeFormat
-	}
-
-	startTime := time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), startTimeParsed.Hour(), startTimeParsed.Minute(), 0, 0, loc)
-	endTime := time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), endTimeParsed.Hour(), endTimeParsed.Minute(), 0, 0, loc)
-
-	utils.Log.WithFields(logrus.Fields{
-		"parsedStartTime": startTime,
-		"parsedEndTime":   endTime,
-	}).Debug("Successfully parsed booking times")
 
-	// === [4] Validasi Waktu ===
-	utils.Log.Debug("Validating booking time constraints")
-	now := time.Now().In(loc)
-	if startTime.Before(now.Add(2 * time.Hour)) {
-		utils.Log.WithFields(logrus.Fields{
-			"startTime":   startTime,
-			"currentTime": now,
-			"minTime":     now.Add(2 * time.Hour),
-		}).Warn("Booking attempt too soon - less than 2 hours notice")
-		return dto.BookingResponse{}, constants.ErrBookingTooSoon
-	}
-	if !endTime.After(startTime) {
-		utils.Log.WithFields(logrus.Fields{
-			"startTime": startTime,
-			"endTime":   endTime,
-		}).Warn("Invalid time range - end time not after start time")
-		return dto.BookingResponse{}, constants.ErrInvalidTimeRange
+	// === [5] Validasi Kode Promo ===
+	var voucher *model.Voucher
+	if req.PromoCode != "" {
+		resolved, err := bs.voucherService.ResolvePromoCode(ctx, req.PromoCode, userID, field)
+		if err != nil {
+			return dto.BookingResponse{}, err
+		}
+		voucher = &resolved
 	}
 
-	// === [5] Validasi Field ===
-	utils.Log.WithField("fieldID", req.FieldID).Debug("Validating field existence and calculating payment")
-	field, _, err := bs.fieldRepo.GetFieldByID(ctx, nil, req.FieldID)
+	// === [6] Hitung Harga ===
+	price, err := bs.pricingService.CalculatePrice(ctx, PriceParams{
+		Field:     field,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Voucher:   voucher,
+	})
 	if err != nil {
-		utils.Log.WithError(err).WithField("fieldID", req.FieldID).Error("Field not found")
-		return dto.BookingResponse{}, constants.ErrFieldNotFound
+		if errors.Is(err, constants.ErrVoucherMinSpend) {
+			return dto.BookingResponse{}, err
+		}
+		utils.Log.WithError(err).WithField("fieldID", req.FieldID).Error("Failed to calculate booking price")
+		return dto.BookingResponse{}, constants.ErrCalculatePrice
 	}
 
-	durationHours := endTime.Sub(startTime).Hours()
-	expectedTotal := float64(field.FieldPrice) * durationHours
-	if math.Abs(req.TotalPayment-expectedTotal) > 1 {
+	// The client total is optional and only used to detect a stale price on the checkout screen.
+	if req.TotalPayment > 0 && math.Abs(req.TotalPayment-price.Total) > 1 {
 		utils.Log.WithFields(logrus.Fields{
-			"expectedTotal":   expectedTotal,
-			"providedTotal":   req.TotalPayment,
-			"fieldPrice":      field.FieldPrice,
-			"durationHours":   durationHours,
+			"expectedTotal": price.Total,
+			"providedTotal": req.TotalPayment,
 		}).Warn("Invalid total payment amount")
 		return dto.BookingResponse{}, constants.ErrInvalidTotalPayment
 	}
 
 	utils.Log.WithFields(logrus.Fields{
-		"fieldName":     field.FieldName,
-		"fieldPrice":    field.FieldPrice,
-		"duration":      durationHours,
-		"totalPayment":  expectedTotal,
-	}).Info("Field validation successful")
-
-	// === [6] Validasi Jadwal Field ===
-	dayOfWeek := int(bookingDate.Weekday())
-	utils.Log.WithFields(logrus.Fields{
-		"fieldID":   req.FieldID,
-		"dayOfWeek": dayOfWeek,
-	}).Debug("Checking field schedule")
-
-	schedule, err := bs.scheduleRepo.GetScheduleByFieldIDAndDay(ctx, nil, req.FieldID, dayOfWeek)
-	if err != nil {
-		utils.Log.WithError(err).WithFields(logrus.Fields{
-			"fieldID":   req.FieldID,
-			"dayOfWeek": dayOfWeek,
-		}).Error("Schedule not found for field and day")
-		return dto.BookingResponse{}, constants.ErrScheduleNotFound
-	}
-
-	openTime := time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), schedule.OpenTime.Hour(), schedule.OpenTime.Minute(), 0, 0, loc)
-	closeTime := time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), schedule.CloseTime.Hour(), schedule.CloseTime.Minute(), 0, 0, loc)
-	if startTime.Before(openTime) || endTime.After(closeTime) {
-		utils.Log.WithFields(logrus.Fields{
-			"requestedStart": startTime,
-			"requestedEnd":   endTime,
-			"openTime":       openTime,
-			"closeTime":      closeTime,
-		}).Warn("Booking time outside operating hours")
-		return dto.BookingResponse{}, constants.ErrOutsideOperatingHours
-	}
-
-	utils.Log.WithFields(logrus.Fields{
-		"openTime":  openTime,
-		"closeTime": closeTime,
-	}).Debug("Schedule validation successful")
-
-	// === [7] Validasi Overlap Booking ===
-	utils.Log.Debug("Checking for booking overlaps")
-	overlap, err := bs.bookingRepo.CheckBookingOverlap(ctx, nil, fieldID, bookingDate, startTime, endTime)
-	if err != nil {
-		utils.Log.WithError(err).WithFields(logrus.Fields{
-			"fieldID":     fieldID,
-			"bookingDate": bookingDate,
-			"startTime":   startTime,
-			"endTime":     endTime,
-		}).Error("Failed to check booking overlap")
-		return dto.BookingResponse{}, constants.ErrCheckOverlap
-	}
-	if overlap {
-		utils.Log.WithFields(logrus.Fields{
-			"fieldID":     fieldID,
-			"bookingDate": bookingDate,
-			"startTime":   startTime,
-			"endTime":     endTime,
-		}).Warn("Booking time slot already occupied")
-		return dto.BookingResponse{}, constants.ErrBookingOverlap
-	}
+		"fieldName":    field.FieldName,
+		"fieldPrice":   field.FieldPrice,
+		"totalPayment": price.Total,
+	}).Info("Booking price calculated")
 
-	utils.Log.Debug("No booking overlap found")
-
-	// === [8] Handle Bukti Pembayaran (Opsional) ===
+	// === [7] Handle Bukti Pembayaran (Opsional) ===
 	var proofPath string
 	var paymentUploadedAt *time.Time
 	status := constants.ENUM_STATUS_BOOKING_PENDING
+	paymentDueAt := paymentDeadline(time.Now().In(loc), startTime)
 
 	if req.ProofPayment != nil {
 		utils.Log.Debug("Processing payment proof upload")
-		imageName, err := helpers.SaveImage(req.ProofPayment, "./assets/proof", "proof")
+		imageName, err := storage.PutUpload(ctx, bs.store, req.ProofPayment, storage.FolderProofs, "proof")
 		if err != nil {
 			utils.Log.WithError(err).Error("Failed to save payment proof image")
 			return dto.BookingResponse{}, constants.ErrSaveImages
@@ -242,7 +194,8 @@ func (bs *BookingService) CreateBooking(ctx context.Context, req dto.CreateBooki
 		status = constants.ENUM_STATUS_BOOKING_WAITING
 		now := time.Now().In(loc)
 		paymentUploadedAt = &now
-		
+		paymentDueAt = nil
+
 		utils.Log.WithFields(logrus.Fields{
 			"proofPath":         proofPath,
 			"paymentUploadedAt": paymentUploadedAt,
@@ -252,7 +205,7 @@ func (bs *BookingService) CreateBooking(ctx context.Context, req dto.CreateBooki
 		utils.Log.Debug("No payment proof provided, booking set to pending status")
 	}
 
-	// === [9] Simpan Booking ===
+	// === [8] Siapkan Booking ===
 	bookingID := uuid.New()
 	booking := model.Booking{
 		BookingID:         bookingID,
@@ -262,12 +215,15 @@ func (bs *BookingService) CreateBooking(ctx context.Context, req dto.CreateBooki
 		BookingDate:       bookingDate,
 		StartTime:         startTime,
 		EndTime:           endTime,
-		TotalPayment:      req.TotalPayment,
+		TotalPayment:      price.Total,
 		ProofPayment:      proofPath,
 		Status:            status,
+		PaymentDueAt:      paymentDueAt,
 		PaymentUploadedAt: paymentUploadedAt,
+		PriceItems:        toBookingPriceItems(bookingID, price.Items),
 	}
 
+	// === [9] Validasi Overlap & Simpan Booking (dalam satu transaksi) ===
 	utils.Log.WithFields(logrus.Fields{
 		"bookingID":     bookingID,
 		"userID":        userID,
@@ -276,9 +232,29 @@ func (bs *BookingService) CreateBooking(ctx context.Context, req dto.CreateBooki
 		"status":        status,
 	}).Info("Attempting to save booking to database")
 
-	if err := bs.bookingRepo.CreateBooking(ctx, nil, booking); err != nil {
-		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to create booking in database")
-		return dto.BookingResponse{}, constants.ErrCreateBooking
+	err = reserveBookingSlot(ctx, bs.bookingRepo, bs.waitlistService, booking, func(tx *gorm.DB) error {
+		if err := bs.bookingRepo.CreateBooking(ctx, tx, booking); err != nil {
+			if repository.IsExclusionViolation(err) {
+				return err
+			}
+			utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to create booking in database")
+			return constants.ErrCreateBooking
+		}
+		if voucher != nil {
+			if err := bs.voucherService.RedeemVoucher(ctx, tx, *voucher, booking, price.Discount); err != nil {
+				return err
+			}
+		}
+		if paymentUploadedAt != nil {
+			if err := bs.savePaymentProof(ctx, tx, booking, user, *paymentUploadedAt); err != nil {
+				return err
+			}
+		}
+		return recordBookingStatus(ctx, tx, bs.bookingRepo, booking, "", user, "", time.Now().In(loc))
+	})
+	if err != nil {
+		bs.removePaymentProof(ctx, proofPath)
+		return dto.BookingResponse{}, err
 	}
 
 	utils.Log.WithField("bookingID", bookingID).Info("Booking created successfully")
@@ -295,12 +271,247 @@ func (bs *BookingService) CreateBooking(ctx context.Context, req dto.CreateBooki
 		TotalPayment:  booking.TotalPayment,
 		Status:        booking.Status,
 		ProofPayment:  booking.ProofPayment,
+		PaymentDueAt:  booking.PaymentDueAt,
+		PriceItems:    price.Items,
 	}
 
 	utils.Log.WithField("bookingID", bookingID).Info("Booking creation process completed successfully")
 	return response, nil
 }
 
+// paymentDeadline returns when an unpaid booking made at now expires. The deadline never
+// falls inside the minimum lead time, so an expired slot can still be booked by someone else.
+func paymentDeadline(now, startTime time.Time) *time.Time {
+	deadline := now.Add(time.Duration(helpers.GetEnvInt("BOOKING_PAYMENT_WINDOW_MINUTES", 60)) * time.Minute)
+	if latest := startTime.Add(-bookingMinLeadTime); latest.Before(deadline) {
+		deadline = latest
+	}
+
+	return &deadline
+}
+
+func parseBookingWindow(bookingDateStr, startTimeStr, endTimeStr string, loc *time.Location) (time.Time, time.Time, time.Time, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"bookingDate": bookingDateStr,
+		"startTime":   startTimeStr,
+		"endTime":     endTimeStr,
+	}).Debug("Parsing booking date and time")
+
+	bookingDate, err := time.ParseInLocation("2006-01-02", bookingDateStr, loc)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingDate", bookingDateStr).Error("Failed to parse booking date")
+		return time.Time{}, time.Time{}, time.Time{}, constants.ErrInvalidBookingDate
+	}
+
+	startTimeParsed, err := time.ParseInLocation("15:04", startTimeStr, loc)
+	if err != nil {
+		utils.Log.WithError(err).WithField("startTime", startTimeStr).Error("Failed to parse start time")
+		return time.Time{}, time.Time{}, time.Time{}, constants.ErrInvalidTimeFormat
+	}
+	endTimeParsed, err := time.ParseInLocation("15:04", endTimeStr, loc)
+	if err != nil {
+		utils.Log.WithError(err).WithField("endTime", endTimeStr).Error("Failed to parse end time")
+		return time.Time{}, time.Time{}, time.Time{}, constants.ErrInvalidTimeFormat
+	}
+
+	startTime := time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), startTimeParsed.Hour(), startTimeParsed.Minute(), 0, 0, loc)
+	endTime := time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), endTimeParsed.Hour(), endTimeParsed.Minute(), 0, 0, loc)
+
+	utils.Log.WithFields(logrus.Fields{
+		"parsedStartTime": startTime,
+		"parsedEndTime":   endTime,
+	}).Debug("Successfully parsed booking times")
+
+	return bookingDate, startTime, endTime, nil
+}
+
+// validateBookingSlot checks the notice period, time range, field and operating hours
+// for a slot and returns the field it belongs to. It does not check for overlaps.
+func validateBookingSlot(ctx context.Context, fieldRepo repository.IFieldRepository, scheduleRepo repository.IScheduleRepository, fieldIDStr string, bookingDate, startTime, endTime time.Time) (model.Field, error) {
+	loc := helpers.GetAppLocation()
+
+	// === Validasi Waktu ===
+	utils.Log.Debug("Validating booking time constraints")
+	now := time.Now().In(loc)
+	if startTime.Before(now.Add(bookingMinLeadTime)) {
+		utils.Log.WithFields(logrus.Fields{
+			"startTime":   startTime,
+			"currentTime": now,
+			"minTime":     now.Add(bookingMinLeadTime),
+		}).Warn("Booking attempt too soon - less than 2 hours notice")
+		return model.Field{}, constants.ErrBookingTooSoon
+	}
+	if !endTime.After(startTime) {
+		utils.Log.WithFields(logrus.Fields{
+			"startTime": startTime,
+			"endTime":   endTime,
+		}).Warn("Invalid time range - end time not after start time")
+		return model.Field{}, constants.ErrInvalidTimeRange
+	}
+
+	// === Validasi Field ===
+	utils.Log.WithField("fieldID", fieldIDStr).Debug("Validating field existence")
+	field, _, err := fieldRepo.GetFieldByID(ctx, nil, fieldIDStr)
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", fieldIDStr).Error("Field not found")
+		return model.Field{}, constants.ErrFieldNotFound
+	}
+
+	// === Validasi Jadwal Field ===
+	dayOfWeek := int(bookingDate.Weekday())
+	utils.Log.WithFields(logrus.Fields{
+		"fieldID":   fieldIDStr,
+		"dayOfWeek": dayOfWeek,
+	}).Debug("Checking field schedule")
+
+	schedule, err := scheduleRepo.GetScheduleByFieldIDAndDay(ctx, nil, fieldIDStr, dayOfWeek)
+	if err != nil {
+		utils.Log.WithError(err).WithFields(logrus.Fields{
+			"fieldID":   fieldIDStr,
+			"dayOfWeek": dayOfWeek,
+		}).Error("Schedule not found for field and day")
+		return model.Field{}, constants.ErrScheduleNotFound
+	}
+
+	openTime := time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), schedule.OpenTime.Hour(), schedule.OpenTime.Minute(), 0, 0, loc)
+	closeTime := time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), schedule.CloseTime.Hour(), schedule.CloseTime.Minute(), 0, 0, loc)
+	if startTime.Before(openTime) || endTime.After(closeTime) {
+		utils.Log.WithFields(logrus.Fields{
+			"requestedStart": startTime,
+			"requestedEnd":   endTime,
+			"openTime":       openTime,
+			"closeTime":      closeTime,
+		}).Warn("Booking time outside operating hours")
+		return model.Field{}, constants.ErrOutsideOperatingHours
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"openTime":  openTime,
+		"closeTime": closeTime,
+	}).Debug("Schedule validation successful")
+
+	return field, nil
+}
+
+// reserveBookingSlot runs write inside a transaction once the slot of booking is known
+// to be free and not held for someone else on the waitlist. The advisory lock serialises
+// concurrent writes for the same field, and the bookings_no_overlap exclusion constraint
+// is the final guard at the database level. The booking itself is ignored by the overlap
+// check, so write may also move it. Waitlist entries the booking satisfies are closed.
+func reserveBookingSlot(ctx context.Context, bookingRepo repository.IBookingRepository, waitlistService IWaitlistService, booking model.Booking, write func(tx *gorm.DB) error) error {
+	logFields := logrus.Fields{
+		"bookingID":   booking.BookingID,
+		"fieldID":     booking.FieldID,
+		"bookingDate": booking.BookingDate,
+		"startTime":   booking.StartTime,
+		"endTime":     booking.EndTime,
+	}
+
+	err := bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := bookingRepo.LockFieldForBooking(ctx, tx, booking.FieldID); err != nil {
+			utils.Log.WithError(err).WithField("fieldID", booking.FieldID).Error("Failed to acquire booking lock for field")
+			return constants.ErrCheckOverlap
+		}
+
+		utils.Log.Debug("Checking for booking overlaps")
+		overlap, err := bookingRepo.CheckBookingOverlap(ctx, tx, booking.FieldID, booking.BookingDate, booking.StartTime, booking.EndTime, booking.BookingID)
+		if err != nil {
+			utils.Log.WithError(err).WithFields(logFields).Error("Failed to check booking overlap")
+			return constants.ErrCheckOverlap
+		}
+		if overlap {
+			utils.Log.WithFields(logFields).Warn("Booking time slot already occupied")
+			return constants.ErrBookingOverlap
+		}
+
+		if err := waitlistService.CheckSlotHold(ctx, tx, booking); err != nil {
+			return err
+		}
+
+		if err := write(tx); err != nil {
+			return err
+		}
+
+		return waitlistService.FulfillWaitlist(ctx, tx, booking)
+	})
+	if repository.IsExclusionViolation(err) {
+		utils.Log.WithError(err).WithFields(logFields).Warn("Booking rejected by overlap constraint")
+		return constants.ErrBookingOverlap
+	}
+
+	return err
+}
+
+func (bs *BookingService) QuoteBooking(ctx context.Context, req dto.QuoteBookingRequest) (dto.QuoteBookingResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"fieldID":     req.FieldID,
+		"bookingDate": req.BookingDate,
+		"startTime":   req.StartTime,
+		"endTime":     req.EndTime,
+	}).Info("Quoting booking price")
+
+	loc := helpers.GetAppLocation()
+
+	fieldID, err := uuid.Parse(req.FieldID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", req.FieldID).Error("Failed to parse field ID")
+		return dto.QuoteBookingResponse{}, constants.ErrInvalidUUID
+	}
+
+	bookingDate, startTime, endTime, err := parseBookingWindow(req.BookingDate, req.StartTime, req.EndTime, loc)
+	if err != nil {
+		return dto.QuoteBookingResponse{}, err
+	}
+
+	field, err := validateBookingSlot(ctx, bs.fieldRepo, bs.scheduleRepo, req.FieldID, bookingDate, startTime, endTime)
+	if err != nil {
+		return dto.QuoteBookingResponse{}, err
+	}
+
+	var voucher *model.Voucher
+	if req.PromoCode != "" {
+		user, err := actorFromContext(ctx, bs.jwtService)
+		if err != nil {
+			return dto.QuoteBookingResponse{}, err
+		}
+		resolved, err := bs.voucherService.ResolvePromoCode(ctx, req.PromoCode, user.UserID, field)
+		if err != nil {
+			return dto.QuoteBookingResponse{}, err
+		}
+		voucher = &resolved
+	}
+
+	price, err := bs.pricingService.CalculatePrice(ctx, PriceParams{
+		Field:     field,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Voucher:   voucher,
+	})
+	if err != nil {
+		if errors.Is(err, constants.ErrVoucherMinSpend) {
+			return dto.QuoteBookingResponse{}, err
+		}
+		utils.Log.WithError(err).WithField("fieldID", req.FieldID).Error("Failed to calculate booking price")
+		return dto.QuoteBookingResponse{}, constants.ErrCalculatePrice
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"fieldID":      fieldID,
+		"totalPayment": price.Total,
+	}).Info("Booking price quoted successfully")
+
+	return dto.QuoteBookingResponse{
+		FieldID:       fieldID,
+		BookingDate:   bookingDate.Format("2006-01-02"),
+		StartTime:     startTime.Format("15:04"),
+		EndTime:       endTime.Format("15:04"),
+		DurationHours: endTime.Sub(startTime).Hours(),
+		Items:         price.Items,
+		Discount:      price.Discount,
+		TotalPayment:  price.Total,
+	}, nil
+}
+
 func (bs *BookingService) GetAllBooking(ctx context.Context, req dto.BookingPaginationRequest) (dto.BookingPaginationResponse, error) {
 	utils.Log.WithFields(logrus.Fields{
 		"page":    req.Page,
@@ -409,9 +620,16 @@ func (bs *BookingService) GetUserBookingHistory(ctx context.Context, req dto.Boo
 	}, nil
 }
 
+// GetBookingByID returns the full booking, including the customer's contact details, so
+// only the customer who made it and admins may read it. GenerateInvoice relies on this.
 func (bs *BookingService) GetBookingByID(ctx context.Context, bookingID string) (dto.BookingFullResponse, error) {
 	utils.Log.WithField("bookingID", bookingID).Info("Fetching booking by ID")
 
+	requester, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.BookingFullResponse{}, err
+	}
+
 	if _, err := uuid.Parse(bookingID); err != nil {
 		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Invalid booking ID format")
 		return dto.BookingFullResponse{}, constants.ErrInvalidUUID
@@ -420,7 +638,11 @@ func (bs *BookingService) GetBookingByID(ctx context.Context, bookingID string)
 	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, bookingID)
 	if err != nil {
 		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to fetch booking by ID")
-		return dto.BookingFullResponse{}, constants.ErrGetFieldByID
+		return dto.BookingFullResponse{}, constants.ErrGetBookingByID
+	}
+
+	if err := authorize(requester, booking.UserID, ownerOrAdmin, "view booking", logrus.Fields{"bookingID": bookingID}); err != nil {
+		return dto.BookingFullResponse{}, err
 	}
 
 	field := booking.Field
@@ -433,6 +655,7 @@ func (bs *BookingService) GetBookingByID(ctx context.Context, bookingID string)
 		FieldAddress: field.FieldAddress,
 		FieldPrice:   field.FieldPrice,
 		FieldImage:   field.FieldImage,
+		ImageURLs:    fieldImageURLs(field.FieldImage),
 		Category: dto.CategoryCompactResponse{
 			CategoryID:  category.CategoryID,
 			Name:        category.Name,
@@ -450,6 +673,7 @@ func (bs *BookingService) GetBookingByID(ctx context.Context, bookingID string)
 
 	res := dto.BookingFullResponse{
 		BookingID:         booking.BookingID,
+		SeriesID:          booking.SeriesID,
 		PaymentMethod:     booking.PaymentMethod,
 		BookingDate:       booking.BookingDate,
 		StartTime:         booking.StartTime,
@@ -460,8 +684,40 @@ func (bs *BookingService) GetBookingByID(ctx context.Context, bookingID string)
 		PaymentUploadedAt: booking.PaymentUploadedAt,
 		PaymentVerifiedAt: booking.PaymentVerifiedAt,
 		CancelledAt:       booking.CancelledAt,
+		CancelReason:      booking.CancelReason,
+		RefundAmount:      booking.RefundAmount,
+		PaymentAdjustment: booking.PaymentAdjustment,
+		RescheduleCount:   booking.RescheduleCount,
+		PaymentDueAt:      booking.PaymentDueAt,
+		CheckedInAt:       booking.CheckedInAt,
 		User:              userDTO,
 		Field:             fieldDTO,
+		PriceItems:        toPriceItemResponses(booking.PriceItems),
+	}
+
+	if redemption := booking.Redemption; redemption != nil {
+		res.Voucher = &dto.VoucherRedemptionResponse{
+			VoucherID:      redemption.VoucherID,
+			Code:           redemption.Code,
+			DiscountAmount: redemption.DiscountAmount,
+			RedeemedAt:     redemption.RedeemedAt,
+		}
+	}
+
+	proofs, err := bs.bookingRepo.GetPaymentProofs(ctx, nil, bookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to fetch payment proof history")
+		return dto.BookingFullResponse{}, constants.ErrGetBookingByID
+	}
+	for _, proof := range proofs {
+		res.ProofHistory = append(res.ProofHistory, dto.PaymentProofResponse{
+			ProofID:      proof.ProofID,
+			FileName:     proof.FileName,
+			UploadedBy:   proof.UploadedBy,
+			UploadedAt:   proof.UploadedAt,
+			RejectedAt:   proof.RejectedAt,
+			RejectReason: proof.RejectReason,
+		})
 	}
 
 	utils.Log.WithFields(logrus.Fields{
@@ -474,106 +730,774 @@ func (bs *BookingService) GetBookingByID(ctx context.Context, bookingID string)
 	return res, nil
 }
 
-func (bs *BookingService) UpdateBookingStatus(ctx context.Context, req dto.UpdateBookingStatusRequest) (dto.BookingResponse, error) {
-	utils.Log.WithFields(logrus.Fields{
-		"bookingID": req.BookingID,
-		"newStatus": *req.Status,
-	}).Info("Starting booking status update")
+// GetPaymentQR renders the QRIS code a customer scans to pay an unpaid booking.
+func (bs *BookingService) GetPaymentQR(ctx context.Context, bookingID string) ([]byte, error) {
+	utils.Log.WithField("bookingID", bookingID).Info("Generating payment QR for booking")
 
-	loc := helpers.GetAppLocation()
+	user, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return nil, err
+	}
 
-	// ====== 1. Validasi UUID Booking ID ======
-	if _, err := uuid.Parse(req.BookingID); err != nil {
-		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Invalid booking ID format")
-		return dto.BookingResponse{}, constants.ErrInvalidUUID
+	if _, err := uuid.Parse(bookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Invalid booking ID format")
+		return nil, constants.ErrInvalidUUID
 	}
 
-	// ====== 2. Ambil Booking dari Database ======
-	utils.Log.WithField("bookingID", req.BookingID).Debug("Fetching booking from database")
-	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, req.BookingID)
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, bookingID)
 	if err != nil {
-		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Booking not found")
-		return dto.BookingResponse{}, constants.ErrBookingNotFound
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Booking not found")
+		return nil, constants.ErrBookingNotFound
 	}
 
-	utils.Log.WithFields(logrus.Fields{
-		"bookingID":    req.BookingID,
-		"currentStatus": booking.Status,
-	}).Debug("Current booking status retrieved")
-
-	// ====== 3. Cek Status Saat Ini ======
-	if booking.Status == constants.ENUM_STATUS_BOOKING_CALCEL || booking.Status == constants.ENUM_STATUS_BOOKING_BOOKED {
-		utils.Log.WithFields(logrus.Fields{
-			"bookingID":     req.BookingID,
-			"currentStatus": booking.Status,
-			"requestedStatus": *req.Status,
-		}).Warn("Cannot update status - booking already in final state")
-		return dto.BookingResponse{}, constants.ErrBookingAlreadyFinal
+	if err := authorize(user, booking.UserID, ownerOrAdmin, "view booking payment QR", logrus.Fields{"bookingID": bookingID}); err != nil {
+		return nil, err
 	}
 
-	// ====== 4. Validasi Status Baru ======
-	newStatus := strings.ToLower(*req.Status)
-	if newStatus != constants.ENUM_STATUS_BOOKING_CALCEL && newStatus != constants.ENUM_STATUS_BOOKING_BOOKED {
+	if booking.Status != constants.ENUM_STATUS_BOOKING_PENDING {
 		utils.Log.WithFields(logrus.Fields{
-			"bookingID":       req.BookingID,
-			"requestedStatus": newStatus,
-		}).Error("Invalid status update requested")
-		return dto.BookingResponse{}, constants.ErrInvalidStatusUpdate
+			"bookingID": bookingID,
+			"status":    booking.Status,
+		}).Warn("Payment QR requested for booking that is not awaiting payment")
+		return nil, constants.ErrPaymentNotAllowed
 	}
 
-	// ====== 5. Update Status & Timestamp ======
-	now := time.Now().In(loc)
-	oldStatus := booking.Status
-	booking.Status = newStatus
+	payload, err := bookingQRISPayload(booking.BookingID, booking.TotalPayment)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to build QRIS payload")
+		if errors.Is(err, helpers.ErrQRISNotConfigured) {
+			return nil, constants.ErrQRISNotConfigured
+		}
+		return nil, constants.ErrGeneratePaymentQR
+	}
 
-	if newStatus == constants.ENUM_STATUS_BOOKING_BOOKED {
-		booking.PaymentVerifiedAt = &now
-		utils.Log.WithFields(logrus.Fields{
-			"bookingID":          req.BookingID,
-			"paymentVerifiedAt": now,
-		}).Info("Booking approved and payment verified")
-	} else if newStatus == constants.ENUM_STATUS_BOOKING_CALCEL {
-		booking.CancelledAt = &now
-		utils.Log.WithFields(logrus.Fields{
-			"bookingID":    req.BookingID,
-			"cancelledAt": now,
-		}).Info("Booking cancelled")
+	png, err := qrcode.Encode(payload, qrcode.Medium, 512)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to encode payment QR")
+		return nil, constants.ErrGeneratePaymentQR
 	}
 
-	// ====== 6. Simpan ke Database ======
-	utils.Log.WithField("bookingID", req.BookingID).Debug("Saving updated booking to database")
-	if err := bs.bookingRepo.UpdateBooking(ctx, nil, booking); err != nil {
-		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Failed to update booking in database")
-		return dto.BookingResponse{}, constants.ErrUpdateBooking
+	return png, nil
+}
+
+// GenerateInvoice renders the invoice PDF. Unpaid bookings also carry the QRIS code so
+// the printed invoice can be paid directly.
+func (bs *BookingService) GenerateInvoice(ctx context.Context, bookingID string) ([]byte, error) {
+	booking, err := bs.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
 	}
 
-	utils.Log.WithFields(logrus.Fields{
+	var paymentQR string
+	if booking.Status == constants.ENUM_STATUS_BOOKING_PENDING {
+		paymentQR, err = bookingQRISPayload(booking.BookingID, booking.TotalPayment)
+		if err != nil {
+			// The invoice is still useful without the QR, so only log it.
+			utils.Log.WithError(err).WithField("bookingID", bookingID).Warn("Invoice generated without payment QR")
+		}
+	}
+
+	checkInToken := helpers.SignCheckInToken(booking.BookingID, helpers.CheckInSecret())
+
+	pdfBytes, err := utils.GenerateInvoicePDF(booking, checkInToken, paymentQR)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to generate invoice")
+		return nil, err
+	}
+
+	return pdfBytes, nil
+}
+
+// bookingQRISPayload builds the dynamic QRIS string for a booking, using a compact form
+// of the booking ID as the bill number (QRIS caps it at 25 characters).
+func bookingQRISPayload(bookingID uuid.UUID, amount float64) (string, error) {
+	reference := strings.ToUpper(strings.ReplaceAll(bookingID.String(), "-", ""))[:20]
+	return helpers.BuildQRISPayload(helpers.QRISMerchantFromEnv(), amount, "FR"+reference)
+}
+
+func (bs *BookingService) GetBookingTimeline(ctx context.Context, bookingID string) (dto.BookingTimelineResponse, error) {
+	utils.Log.WithField("bookingID", bookingID).Info("Fetching booking status timeline")
+
+	user, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.BookingTimelineResponse{}, err
+	}
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Invalid booking ID format")
+		return dto.BookingTimelineResponse{}, constants.ErrInvalidUUID
+	}
+
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, bookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Booking not found")
+		return dto.BookingTimelineResponse{}, constants.ErrBookingNotFound
+	}
+
+	if err := authorize(user, booking.UserID, ownerOrAdmin, "view booking timeline", logrus.Fields{"bookingID": bookingID}); err != nil {
+		return dto.BookingTimelineResponse{}, err
+	}
+
+	histories, err := bs.bookingRepo.GetBookingStatusHistory(ctx, nil, bookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to fetch booking status history")
+		return dto.BookingTimelineResponse{}, constants.ErrGetBookingTimeline
+	}
+
+	res := dto.BookingTimelineResponse{
+		BookingID:     booking.BookingID,
+		CurrentStatus: booking.Status,
+		History:       []dto.BookingStatusHistoryResponse{},
+	}
+	for _, history := range histories {
+		res.History = append(res.History, dto.BookingStatusHistoryResponse{
+			FromStatus: history.FromStatus,
+			ToStatus:   history.ToStatus,
+			ChangedBy:  history.ChangedBy,
+			ActorRole:  history.ActorRole,
+			Reason:     history.Reason,
+			ChangedAt:  history.ChangedAt,
+		})
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID": bookingID,
+		"entries":   len(res.History),
+	}).Info("Successfully fetched booking status timeline")
+
+	return res, nil
+}
+
+func (bs *BookingService) UpdateBookingStatus(ctx context.Context, req dto.UpdateBookingStatusRequest) (dto.BookingResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID": req.BookingID,
+		"newStatus": *req.Status,
+	}).Info("Starting booking status update")
+
+	admin, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	// ====== 1. Validasi UUID Booking ID ======
+	if _, err := uuid.Parse(req.BookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Invalid booking ID format")
+		return dto.BookingResponse{}, constants.ErrInvalidUUID
+	}
+
+	// ====== 2. Ambil Booking dari Database ======
+	utils.Log.WithField("bookingID", req.BookingID).Debug("Fetching booking from database")
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, req.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Booking not found")
+		return dto.BookingResponse{}, constants.ErrBookingNotFound
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID":     req.BookingID,
+		"currentStatus": booking.Status,
+	}).Debug("Current booking status retrieved")
+
+	// ====== 3. Terapkan Transisi Status ======
+	oldStatus := booking.Status
+	newStatus := strings.ToLower(*req.Status)
+
+	err = bs.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		return applyBookingTransition(ctx, tx, bs.bookingRepo, &booking, newStatus, admin, req.Reason)
+	})
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	if isBookingActive(oldStatus) && !isBookingActive(newStatus) {
+		bs.waitlistService.ReleaseSlot(ctx, booking)
+	}
+
+	utils.Log.WithFields(logrus.Fields{
 		"bookingID": req.BookingID,
 		"oldStatus": oldStatus,
 		"newStatus": newStatus,
 	}).Info("Booking status updated successfully")
 
-	// ====== 7. Response DTO ======
-	return dto.BookingResponse{
-		BookingID:         booking.BookingID,
-		UserID:            booking.UserID,
-		FieldID:           booking.FieldID,
-		BookingDate:       booking.BookingDate,
-		StartTime:         booking.StartTime,
-		EndTime:           booking.EndTime,
-		PaymentMethod:     booking.PaymentMethod,
-		TotalPayment:      booking.TotalPayment,
-		ProofPayment:      booking.ProofPayment,
-		Status:            booking.Status,
-		PaymentUploadedAt: booking.PaymentUploadedAt,
-		PaymentVerifiedAt: booking.PaymentVerifiedAt,
-		CancelledAt:       booking.CancelledAt,
+	// ====== 4. Response DTO ======
+	booking.PriceItems = nil
+	return toBookingResponse(booking), nil
+}
+
+// CancelBooking lets a customer cancel their own booking. The row is kept with status
+// cancelled and the refund owed under the cancellation policy.
+func (bs *BookingService) CancelBooking(ctx context.Context, req dto.CancelBookingRequest) (dto.BookingResponse, error) {
+	utils.Log.WithField("bookingID", req.BookingID).Info("Starting booking cancellation")
+
+	user, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	if _, err := uuid.Parse(req.BookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Invalid booking ID format")
+		return dto.BookingResponse{}, constants.ErrInvalidUUID
+	}
+
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, req.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Booking not found")
+		return dto.BookingResponse{}, constants.ErrBookingNotFound
+	}
+
+	if err := authorize(user, booking.UserID, ownerOnly, "cancel booking", logrus.Fields{"bookingID": req.BookingID}); err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	now := time.Now().In(helpers.GetAppLocation())
+	err = bs.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		return cancelBookingWithRefund(ctx, tx, bs.bookingRepo, &booking, user, constants.ENUM_CANCEL_REASON_USER_REQUEST, now)
+	})
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	bs.waitlistService.ReleaseSlot(ctx, booking)
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID":    req.BookingID,
+		"refundAmount": booking.RefundAmount,
+	}).Info("Booking cancelled by customer")
+
+	booking.PriceItems = nil
+	return toBookingResponse(booking), nil
+}
+
+// UploadPaymentProof attaches a new transfer proof to an unpaid booking, either after a
+// book-now-pay-later checkout or to replace a wrong upload. Earlier proofs stay in the
+// history; a pending booking moves to waiting_verification.
+func (bs *BookingService) UploadPaymentProof(ctx context.Context, req dto.UploadPaymentProofRequest) (dto.BookingResponse, error) {
+	utils.Log.WithField("bookingID", req.BookingID).Info("Uploading payment proof")
+
+	user, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	if _, err := uuid.Parse(req.BookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Invalid booking ID format")
+		return dto.BookingResponse{}, constants.ErrInvalidUUID
+	}
+
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, req.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Booking not found")
+		return dto.BookingResponse{}, constants.ErrBookingNotFound
+	}
+
+	if err := authorize(user, booking.UserID, ownerOnly, "upload payment proof for booking", logrus.Fields{"bookingID": req.BookingID}); err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	now := time.Now().In(helpers.GetAppLocation())
+	switch booking.Status {
+	case constants.ENUM_STATUS_BOOKING_PENDING:
+		if booking.PaymentDueAt != nil && now.After(*booking.PaymentDueAt) {
+			utils.Log.WithFields(logrus.Fields{
+				"bookingID":    req.BookingID,
+				"paymentDueAt": booking.PaymentDueAt,
+			}).Warn("Payment proof uploaded after payment deadline")
+			return dto.BookingResponse{}, constants.ErrPaymentDeadlinePassed
+		}
+	case constants.ENUM_STATUS_BOOKING_WAITING:
+	default:
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": req.BookingID,
+			"status":    booking.Status,
+		}).Warn("Payment proof not accepted for booking status")
+		return dto.BookingResponse{}, constants.ErrPaymentProofNotAllowed
+	}
+
+	imageName, err := storage.PutUpload(ctx, bs.store, req.ProofPayment, storage.FolderProofs, "proof")
+	if err != nil {
+		utils.Log.WithError(err).Error("Failed to save payment proof image")
+		return dto.BookingResponse{}, constants.ErrSaveImages
+	}
+
+	booking.ProofPayment = imageName
+	booking.PaymentUploadedAt = &now
+
+	err = bs.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := bs.savePaymentProof(ctx, tx, booking, user, now); err != nil {
+			return err
+		}
+
+		if booking.Status == constants.ENUM_STATUS_BOOKING_PENDING {
+			return applyBookingTransition(ctx, tx, bs.bookingRepo, &booking, constants.ENUM_STATUS_BOOKING_WAITING, user, "payment proof uploaded")
+		}
+
+		if err := bs.bookingRepo.UpdateBooking(ctx, tx, booking); err != nil {
+			utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Failed to update booking payment proof")
+			return constants.ErrUpdateBooking
+		}
+		return nil
+	})
+	if err != nil {
+		bs.removePaymentProof(ctx, imageName)
+		return dto.BookingResponse{}, err
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID": req.BookingID,
+		"proofPath": imageName,
+		"status":    booking.Status,
+	}).Info("Payment proof uploaded successfully")
+
+	booking.PriceItems = nil
+	return toBookingResponse(booking), nil
+}
+
+// RejectPaymentProof turns down the latest proof of a booking awaiting verification. The
+// booking goes back to pending with a fresh payment window so the customer can upload a
+// correct proof, and is told why.
+func (bs *BookingService) RejectPaymentProof(ctx context.Context, req dto.RejectPaymentProofRequest) (dto.BookingResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID": req.BookingID,
+		"reason":    req.Reason,
+	}).Info("Rejecting payment proof")
+
+	admin, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	if _, err := uuid.Parse(req.BookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Invalid booking ID format")
+		return dto.BookingResponse{}, constants.ErrInvalidUUID
+	}
+
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, req.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Booking not found")
+		return dto.BookingResponse{}, constants.ErrBookingNotFound
+	}
+
+	if booking.Status != constants.ENUM_STATUS_BOOKING_WAITING {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": req.BookingID,
+			"status":    booking.Status,
+		}).Warn("Booking has no payment proof awaiting review")
+		return dto.BookingResponse{}, constants.ErrProofNotAwaitingReview
+	}
+
+	now := time.Now().In(helpers.GetAppLocation())
+	deadline := paymentDeadline(now, booking.StartTime)
+	if !deadline.After(now) {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": req.BookingID,
+			"startTime": booking.StartTime,
+		}).Warn("Booking too close to start for a new payment window")
+		return dto.BookingResponse{}, constants.ErrRejectProofTooLate
+	}
+
+	proofs, err := bs.bookingRepo.GetPaymentProofs(ctx, nil, req.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Failed to fetch payment proof history")
+		return dto.BookingResponse{}, constants.ErrGetBookingByID
+	}
+
+	booking.PaymentDueAt = deadline
+	err = bs.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		// Proofs uploaded before the history existed have no row to mark.
+		if len(proofs) > 0 {
+			proof := proofs[len(proofs)-1]
+			proof.RejectedAt = &now
+			proof.RejectedBy = &admin.UserID
+			proof.RejectReason = req.Reason
+			if err := bs.bookingRepo.RejectPaymentProof(ctx, tx, proof); err != nil {
+				utils.Log.WithError(err).WithField("proofID", proof.ProofID).Error("Failed to mark payment proof as rejected")
+				return constants.ErrSavePaymentProof
+			}
+		}
+
+		if err := bs.bookingRepo.ClearVerificationFlag(ctx, tx, booking.BookingID); err != nil {
+			utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Failed to clear verification flag")
+			return constants.ErrUpdateBooking
+		}
+		booking.VerificationFlaggedAt = nil
+
+		return applyBookingTransition(ctx, tx, bs.bookingRepo, &booking, constants.ENUM_STATUS_BOOKING_PENDING, admin, req.Reason)
+	})
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	message := fmt.Sprintf("Your payment proof for %s on %s was rejected: %s. Please upload a new proof before %s.",
+		booking.Field.FieldName,
+		booking.StartTime.Format("02 Jan 2006 15:04"),
+		req.Reason,
+		deadline.Format("02 Jan 2006 15:04"))
+	if err := bs.notificationService.Notify(ctx, booking.UserID, "Payment proof rejected", message); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Warn("Failed to notify customer about rejected payment proof")
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID":    req.BookingID,
+		"paymentDueAt": deadline,
+	}).Info("Payment proof rejected")
+
+	booking.PriceItems = nil
+	return toBookingResponse(booking), nil
+}
+
+// GetVerificationQueue lists bookings awaiting proof review, oldest upload first.
+func (bs *BookingService) GetVerificationQueue(ctx context.Context) ([]dto.VerificationQueueItem, error) {
+	utils.Log.Info("Fetching payment verification queue")
+
+	bookings, err := bs.bookingRepo.GetWaitingVerificationBookings(ctx, nil)
+	if err != nil {
+		utils.Log.WithError(err).Error("Failed to get bookings waiting for verification")
+		return nil, constants.ErrGetVerificationQueue
+	}
+
+	res := []dto.VerificationQueueItem{}
+	for _, booking := range bookings {
+		res = append(res, dto.VerificationQueueItem{
+			BookingID:         booking.BookingID,
+			UserName:          booking.User.Name,
+			UserEmail:         booking.User.Email,
+			FieldName:         booking.Field.FieldName,
+			BookingDate:       booking.BookingDate,
+			StartTime:         booking.StartTime,
+			EndTime:           booking.EndTime,
+			PaymentMethod:     booking.PaymentMethod,
+			ExpectedAmount:    booking.TotalPayment,
+			PaymentAdjustment: booking.PaymentAdjustment,
+			ProofURL:          paymentProofURL(booking.BookingID, booking.ProofPayment),
+			PaymentUploadedAt: booking.PaymentUploadedAt,
+			Overdue:           booking.VerificationFlaggedAt != nil,
+		})
+	}
+
+	return res, nil
+}
+
+// paymentProofURL is the authenticated endpoint serving the latest proof of a booking.
+func paymentProofURL(bookingID uuid.UUID, fileName string) string {
+	if fileName == "" {
+		return ""
+	}
+	return fmt.Sprintf("/api/users/booking/%s/payment-proof", bookingID)
+}
+
+// GetPaymentProof returns a booking's proof for its owner or an admin. Without proofID the
+// latest proof is returned. The caller must close the object body.
+func (bs *BookingService) GetPaymentProof(ctx context.Context, bookingID, proofID string) (storage.Object, error) {
+	user, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return storage.Object{}, err
+	}
+
+	booking, key, err := bs.findPaymentProof(ctx, bookingID, proofID)
+	if err != nil {
+		return storage.Object{}, err
+	}
+
+	if err := authorize(user, booking.UserID, ownerOrAdmin, "view payment proof", logrus.Fields{"bookingID": bookingID}); err != nil {
+		return storage.Object{}, err
+	}
+
+	object, err := bs.store.Get(ctx, key)
+	if err != nil {
+		utils.Log.WithError(err).WithField("key", key).Error("Failed to read payment proof")
+		return storage.Object{}, constants.ErrPaymentProofNotFound
+	}
+
+	return object, nil
+}
+
+// GetPaymentProofURL issues a short-lived link to a proof that opens without a session,
+// for admins reviewing slips outside the dashboard.
+func (bs *BookingService) GetPaymentProofURL(ctx context.Context, bookingID, proofID string) (dto.SignedURLResponse, error) {
+	_, key, err := bs.findPaymentProof(ctx, bookingID, proofID)
+	if err != nil {
+		return dto.SignedURLResponse{}, err
+	}
+
+	ttl := time.Duration(helpers.GetEnvInt("PROOF_URL_TTL_MINUTES", 10)) * time.Minute
+	url, err := bs.store.SignedURL(ctx, key, ttl)
+	if err != nil {
+		utils.Log.WithError(err).WithField("key", key).Error("Failed to sign payment proof URL")
+		return dto.SignedURLResponse{}, constants.ErrPaymentProofNotFound
+	}
+
+	return dto.SignedURLResponse{
+		URL:       url,
+		ExpiresAt: time.Now().In(helpers.GetAppLocation()).Add(ttl),
+	}, nil
+}
+
+// findPaymentProof returns the booking and the storage key of the requested proof, which
+// must belong to that booking.
+func (bs *BookingService) findPaymentProof(ctx context.Context, bookingID, proofID string) (model.Booking, string, error) {
+	if _, err := uuid.Parse(bookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Invalid booking ID format")
+		return model.Booking{}, "", constants.ErrInvalidUUID
+	}
+
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, bookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Booking not found")
+		return model.Booking{}, "", constants.ErrBookingNotFound
+	}
+
+	if proofID == "" {
+		if booking.ProofPayment == "" {
+			return model.Booking{}, "", constants.ErrPaymentProofNotFound
+		}
+		return proofKey(booking, booking.ProofPayment)
+	}
+
+	proofs, err := bs.bookingRepo.GetPaymentProofs(ctx, nil, bookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to fetch payment proof history")
+		return model.Booking{}, "", constants.ErrGetBookingByID
+	}
+	for _, proof := range proofs {
+		if proof.ProofID.String() == proofID {
+			return proofKey(booking, proof.FileName)
+		}
+	}
+
+	return model.Booking{}, "", constants.ErrPaymentProofNotFound
+}
+
+func proofKey(booking model.Booking, fileName string) (model.Booking, string, error) {
+	key, err := storage.Key(storage.FolderProofs, fileName)
+	if err != nil {
+		return model.Booking{}, "", constants.ErrPaymentProofNotFound
+	}
+	return booking, key, nil
+}
+
+// removePaymentProof deletes an uploaded proof whose booking update did not go through.
+func (bs *BookingService) removePaymentProof(ctx context.Context, fileName string) {
+	if fileName == "" {
+		return
+	}
+
+	key, err := storage.Key(storage.FolderProofs, fileName)
+	if err == nil {
+		err = bs.store.Delete(ctx, key)
+	}
+	if err != nil {
+		utils.Log.WithError(err).WithField("file", fileName).Warn("Failed to remove orphaned payment proof")
+	}
+}
+
+// savePaymentProof appends the booking's current proof to its upload history.
+func (bs *BookingService) savePaymentProof(ctx context.Context, tx *gorm.DB, booking model.Booking, by actor, uploadedAt time.Time) error {
+	proof := model.PaymentProof{
+		ProofID:    uuid.New(),
+		BookingID:  booking.BookingID,
+		UploadedBy: by.UserID,
+		FileName:   booking.ProofPayment,
+		UploadedAt: uploadedAt,
+	}
+
+	if err := bs.bookingRepo.CreatePaymentProof(ctx, tx, proof); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to save payment proof history")
+		return constants.ErrSavePaymentProof
+	}
+
+	return nil
+}
+
+// RescheduleBooking moves a booking to a new date, time or field in one transaction. The
+// status and payment verification are kept; the price difference against the amount
+// already paid is added to PaymentAdjustment (positive is a top-up, negative a credit).
+func (bs *BookingService) RescheduleBooking(ctx context.Context, req dto.RescheduleBookingRequest) (dto.RescheduleBookingResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID":   req.BookingID,
+		"fieldID":     req.FieldID,
+		"bookingDate": req.BookingDate,
+		"startTime":   req.StartTime,
+		"endTime":     req.EndTime,
+	}).Info("Starting booking reschedule")
+
+	loc := helpers.GetAppLocation()
+
+	user, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.RescheduleBookingResponse{}, err
+	}
+
+	if _, err := uuid.Parse(req.BookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Invalid booking ID format")
+		return dto.RescheduleBookingResponse{}, constants.ErrInvalidUUID
+	}
+
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, req.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Booking not found")
+		return dto.RescheduleBookingResponse{}, constants.ErrBookingNotFound
+	}
+
+	if err := authorize(user, booking.UserID, ownerOnly, "reschedule booking", logrus.Fields{"bookingID": req.BookingID}); err != nil {
+		return dto.RescheduleBookingResponse{}, err
+	}
+
+	// === Validasi Status, Batas Waktu & Jumlah Reschedule ===
+	switch booking.Status {
+	case constants.ENUM_STATUS_BOOKING_PENDING, constants.ENUM_STATUS_BOOKING_WAITING, constants.ENUM_STATUS_BOOKING_BOOKED:
+	default:
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": req.BookingID,
+			"status":    booking.Status,
+		}).Warn("Booking cannot be rescheduled in its current status")
+		return dto.RescheduleBookingResponse{}, constants.ErrRescheduleNotAllowed
+	}
+
+	rescheduleWindow := time.Duration(helpers.GetEnvInt("BOOKING_RESCHEDULE_WINDOW_HOURS", 24)) * time.Hour
+	if time.Until(booking.StartTime) < rescheduleWindow {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": req.BookingID,
+			"startTime": booking.StartTime,
+			"window":    rescheduleWindow,
+		}).Warn("Booking too close to start time to reschedule")
+		return dto.RescheduleBookingResponse{}, constants.ErrRescheduleTooLate
+	}
+
+	if maxReschedules := helpers.GetEnvInt("BOOKING_MAX_RESCHEDULES", 2); booking.RescheduleCount >= maxReschedules {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID":       req.BookingID,
+			"rescheduleCount": booking.RescheduleCount,
+			"maxReschedules":  maxReschedules,
+		}).Warn("Booking reached the maximum number of reschedules")
+		return dto.RescheduleBookingResponse{}, constants.ErrRescheduleLimitReached
+	}
+
+	// === Validasi Slot Baru ===
+	fieldIDStr := req.FieldID
+	if fieldIDStr == "" {
+		fieldIDStr = booking.FieldID.String()
+	}
+	fieldID, err := uuid.Parse(fieldIDStr)
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", fieldIDStr).Error("Failed to parse field ID")
+		return dto.RescheduleBookingResponse{}, constants.ErrInvalidUUID
+	}
+
+	bookingDate, startTime, endTime, err := parseBookingWindow(req.BookingDate, req.StartTime, req.EndTime, loc)
+	if err != nil {
+		return dto.RescheduleBookingResponse{}, err
+	}
+
+	field, err := validateBookingSlot(ctx, bs.fieldRepo, bs.scheduleRepo, fieldIDStr, bookingDate, startTime, endTime)
+	if err != nil {
+		return dto.RescheduleBookingResponse{}, err
+	}
+
+	// A promo code redeemed at checkout keeps applying to the new slot as long as it covers
+	// the new field and date; otherwise it is dropped and the slot is priced without it.
+	voucher, err := bs.voucherService.GetRedeemedVoucher(ctx, booking.BookingID)
+	if err != nil {
+		return dto.RescheduleBookingResponse{}, err
+	}
+	voucherRemoved := voucher != nil && (!voucherAppliesToField(*voucher, field) || !voucherValidOn(*voucher, bookingDate))
+	if voucherRemoved {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": req.BookingID,
+			"voucherID": voucher.VoucherID,
+			"fieldID":   fieldIDStr,
+		}).Info("Redeemed voucher does not apply to the new slot, removing it")
+		voucher = nil
+	}
+
+	price, err := bs.pricingService.CalculatePrice(ctx, PriceParams{
+		Field:     field,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Voucher:   voucher,
+	})
+	if err != nil {
+		if errors.Is(err, constants.ErrVoucherMinSpend) {
+			return dto.RescheduleBookingResponse{}, err
+		}
+		utils.Log.WithError(err).WithField("fieldID", fieldIDStr).Error("Failed to calculate booking price")
+		return dto.RescheduleBookingResponse{}, constants.ErrCalculatePrice
+	}
+
+	// === Hitung Selisih Harga ===
+	previousTotal := booking.TotalPayment
+	priceDifference := roundPrice(price.Total - previousTotal)
+
+	// Unpaid bookings simply take the new price; only paid amounts need settling.
+	if booking.Status != constants.ENUM_STATUS_BOOKING_PENDING {
+		booking.PaymentAdjustment = roundPrice(booking.PaymentAdjustment + priceDifference)
+	}
+	if booking.Status == constants.ENUM_STATUS_BOOKING_PENDING && booking.PaymentDueAt != nil {
+		if latest := startTime.Add(-bookingMinLeadTime); latest.Before(*booking.PaymentDueAt) {
+			booking.PaymentDueAt = &latest
+		}
+	}
+
+	previous := booking
+	booking.FieldID = fieldID
+	booking.BookingDate = bookingDate
+	booking.StartTime = startTime
+	booking.EndTime = endTime
+	booking.TotalPayment = price.Total
+	booking.RescheduleCount++
+
+	// === Simpan (dalam satu transaksi) ===
+	priceItems := toBookingPriceItems(booking.BookingID, price.Items)
+	err = reserveBookingSlot(ctx, bs.bookingRepo, bs.waitlistService, booking, func(tx *gorm.DB) error {
+		if err := bs.bookingRepo.RescheduleBooking(ctx, tx, booking); err != nil {
+			if repository.IsExclusionViolation(err) {
+				return err
+			}
+			utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to reschedule booking in database")
+			return constants.ErrUpdateBooking
+		}
+		if err := bs.bookingRepo.ReplaceBookingPriceItems(ctx, tx, booking.BookingID, priceItems); err != nil {
+			utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to replace booking price items")
+			return constants.ErrUpdateBooking
+		}
+		if voucherRemoved {
+			return bs.voucherService.RemoveRedemption(ctx, tx, booking.BookingID)
+		}
+		if voucher != nil {
+			return bs.voucherService.UpdateRedemptionDiscount(ctx, tx, booking.BookingID, price.Discount)
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.RescheduleBookingResponse{}, err
+	}
+
+	bs.waitlistService.ReleaseSlot(ctx, previous)
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID":         booking.BookingID,
+		"previousTotal":     previousTotal,
+		"newTotal":          booking.TotalPayment,
+		"paymentAdjustment": booking.PaymentAdjustment,
+		"rescheduleCount":   booking.RescheduleCount,
+	}).Info("Booking rescheduled successfully")
+
+	booking.PriceItems = priceItems
+	return dto.RescheduleBookingResponse{
+		Booking:         toBookingResponse(booking),
+		PreviousTotal:   previousTotal,
+		PriceDifference: priceDifference,
+		VoucherRemoved:  voucherRemoved,
 	}, nil
 }
 
 func (bs *BookingService) DeleteBooking(ctx context.Context, req dto.DeleteBookingRequest) (dto.BookingResponse, error) {
 	utils.Log.WithField("bookingID", req.BookingID).Info("Starting booking deletion process")
 
+	admin, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
 	if _, err := uuid.Parse(req.BookingID); err != nil {
 		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Invalid booking ID format")
 		return dto.BookingResponse{}, constants.ErrInvalidUUID
@@ -587,24 +1511,28 @@ func (bs *BookingService) DeleteBooking(ctx context.Context, req dto.DeleteBooki
 		return dto.BookingResponse{}, constants.ErrGetBookingByID
 	}
 
-	// Validasi: hanya bisa dibatalkan maksimal 3 jam sebelum startTime
-	timeUntilStart := time.Until(booking.StartTime)
-	if timeUntilStart < 3*time.Hour {
+	// An active booking is cancelled like any other, so it keeps its status history and
+	// the refund owed. Only bookings that no longer hold a slot are removed.
+	if isBookingActive(booking.Status) {
+		now := time.Now().In(helpers.GetAppLocation())
+		err = bs.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+			return cancelBookingWithRefund(ctx, tx, bs.bookingRepo, &booking, admin, constants.ENUM_CANCEL_REASON_ADMIN_CANCELLED, now)
+		})
+		if err != nil {
+			return dto.BookingResponse{}, err
+		}
+
+		bs.waitlistService.ReleaseSlot(ctx, booking)
+
 		utils.Log.WithFields(logrus.Fields{
-			"bookingID":      req.BookingID,
-			"startTime":      booking.StartTime,
-			"timeUntilStart": timeUntilStart,
-		}).Warn("Cannot cancel booking - less than 3 hours before start time")
-		return dto.BookingResponse{}, constants.ErrCannotCancelLate
-	}
+			"bookingID":    req.BookingID,
+			"refundAmount": booking.RefundAmount,
+		}).Info("Booking cancelled by admin")
 
-	utils.Log.WithFields(logrus.Fields{
-		"bookingID":      req.BookingID,
-		"startTime":      booking.StartTime,
-		"timeUntilStart": timeUntilStart,
-	}).Debug("Cancellation time validation passed")
+		booking.PriceItems = nil
+		return toBookingResponse(booking), nil
+	}
 
-	// Lakukan soft delete atau ubah status
 	utils.Log.WithField("bookingID", req.BookingID).Debug("Performing booking deletion")
 	if err := bs.bookingRepo.DeleteBooking(ctx, nil, req.BookingID); err != nil {
 		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Failed to delete booking")
@@ -613,19 +1541,99 @@ func (bs *BookingService) DeleteBooking(ctx context.Context, req dto.DeleteBooki
 
 	utils.Log.WithField("bookingID", req.BookingID).Info("Booking deleted successfully")
 
+	booking.PriceItems = nil
+	return toBookingResponse(booking), nil
+}
+func toBookingResponse(booking model.Booking) dto.BookingResponse {
 	return dto.BookingResponse{
 		BookingID:         booking.BookingID,
 		UserID:            booking.UserID,
 		FieldID:           booking.FieldID,
+		SeriesID:          booking.SeriesID,
+		PaymentMethod:     booking.PaymentMethod,
 		BookingDate:       booking.BookingDate,
 		StartTime:         booking.StartTime,
 		EndTime:           booking.EndTime,
-		PaymentMethod:     booking.PaymentMethod,
+		Status:            booking.Status,
 		TotalPayment:      booking.TotalPayment,
 		ProofPayment:      booking.ProofPayment,
-		Status:            booking.Status,
+		PaymentDueAt:      booking.PaymentDueAt,
 		PaymentUploadedAt: booking.PaymentUploadedAt,
 		PaymentVerifiedAt: booking.PaymentVerifiedAt,
 		CancelledAt:       booking.CancelledAt,
+		CancelReason:      booking.CancelReason,
+		RefundAmount:      booking.RefundAmount,
+		PaymentAdjustment: booking.PaymentAdjustment,
+		RescheduleCount:   booking.RescheduleCount,
+		PriceItems:        toPriceItemResponses(booking.PriceItems),
+	}
+}
+
+// CheckInBooking is called by venue staff after scanning the QR code on an invoice. The
+// booking must be confirmed and the scan must fall inside its check-in window.
+func (bs *BookingService) CheckInBooking(ctx context.Context, req dto.CheckInRequest) (dto.CheckInResponse, error) {
+	by, err := actorFromContext(ctx, bs.jwtService)
+	if err != nil {
+		return dto.CheckInResponse{}, err
+	}
+	if !by.isAdmin() && by.Role != constants.ENUM_ROLE_STAFF {
+		utils.Log.WithField("userID", by.UserID).Warn("Check-in attempted without staff role")
+		return dto.CheckInResponse{}, constants.ErrDeniedAccess
+	}
+
+	bookingID, err := helpers.ParseCheckInToken(req.Token, helpers.CheckInSecret())
+	if err != nil {
+		utils.Log.WithField("staffID", by.UserID).Warn("Rejected invalid check-in token")
+		return dto.CheckInResponse{}, constants.ErrInvalidCheckInToken
+	}
+
+	booking, _, err := bs.bookingRepo.GetBookingByID(ctx, nil, bookingID.String())
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to get booking for check-in")
+		return dto.CheckInResponse{}, constants.ErrGetBookingByID
+	}
+
+	if booking.CheckedInAt != nil {
+		return dto.CheckInResponse{}, constants.ErrAlreadyCheckedIn
+	}
+	if booking.Status != constants.ENUM_STATUS_BOOKING_BOOKED {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": booking.BookingID,
+			"status":    booking.Status,
+		}).Warn("Check-in attempted for unconfirmed booking")
+		return dto.CheckInResponse{}, constants.ErrCheckInNotAllowed
+	}
+
+	now := time.Now().In(helpers.GetAppLocation())
+	opens, closes := checkInWindow(booking.StartTime)
+	if now.Before(opens) {
+		return dto.CheckInResponse{}, constants.ErrCheckInNotOpen
+	}
+	if !now.Before(closes) {
+		return dto.CheckInResponse{}, constants.ErrCheckInClosed
+	}
+
+	checkedIn, err := bs.bookingRepo.MarkBookingCheckedIn(ctx, nil, booking.BookingID, now, by.UserID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to record check-in")
+		return dto.CheckInResponse{}, constants.ErrUpdateBooking
+	}
+	if !checkedIn {
+		// Another scan of the same code recorded the check-in first.
+		return dto.CheckInResponse{}, constants.ErrAlreadyCheckedIn
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID": booking.BookingID,
+		"staffID":   by.UserID,
+	}).Info("Booking checked in")
+
+	return dto.CheckInResponse{
+		BookingID:   booking.BookingID,
+		UserName:    booking.User.Name,
+		FieldName:   booking.Field.FieldName,
+		StartTime:   booking.StartTime,
+		EndTime:     booking.EndTime,
+		CheckedInAt: now,
 	}, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/helpers"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// bookingTransitions is the booking state machine: every status a booking can be in,
+// mapped to the statuses it may move to next. Statuses without targets are final.
+var bookingTransitions = map[string][]string{
+	constants.ENUM_STATUS_BOOKING_PENDING: {
+		constants.ENUM_STATUS_BOOKING_WAITING,
+		constants.ENUM_STATUS_BOOKING_BOOKED,
+		constants.ENUM_STATUS_BOOKING_CALCEL,
+	},
+	constants.ENUM_STATUS_BOOKING_WAITING: {
+		constants.ENUM_STATUS_BOOKING_PENDING,
+		constants.ENUM_STATUS_BOOKING_BOOKED,
+		constants.ENUM_STATUS_BOOKING_REJECTED,
+		constants.ENUM_STATUS_BOOKING_CALCEL,
+	},
+	constants.ENUM_STATUS_BOOKING_BOOKED: {
+		constants.ENUM_STATUS_BOOKING_COMPLETED,
+		constants.ENUM_STATUS_BOOKING_NO_SHOW,
+		constants.ENUM_STATUS_BOOKING_CALCEL,
+		constants.ENUM_STATUS_BOOKING_REFUNDED,
+	},
+	constants.ENUM_STATUS_BOOKING_CALCEL: {
+		constants.ENUM_STATUS_BOOKING_REFUNDED,
+	},
+	constants.ENUM_STATUS_BOOKING_REJECTED:  {},
+	constants.ENUM_STATUS_BOOKING_COMPLETED: {},
+	constants.ENUM_STATUS_BOOKING_NO_SHOW:   {},
+	constants.ENUM_STATUS_BOOKING_REFUNDED:  {},
+}
+
+// systemActor is recorded for transitions made by background jobs rather than a user.
+var systemActor = actor{Role: constants.ENUM_ACTOR_SYSTEM}
+
+func isValidBookingStatus(status string) bool {
+	_, ok := bookingTransitions[status]
+	return ok
+}
+
+// isBookingActive reports whether a booking in status still holds its slot.
+func isBookingActive(status string) bool {
+	for _, inactive := range constants.InactiveBookingStatuses {
+		if status == inactive {
+			return false
+		}
+	}
+
+	return true
+}
+
+func canTransitionBooking(from, to string) bool {
+	for _, next := range bookingTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyBookingTransition moves booking to status, stamps the matching timestamp, saves it
+// and appends a status history row. Pass a transaction to keep both writes atomic.
+func applyBookingTransition(ctx context.Context, tx *gorm.DB, bookingRepo repository.IBookingRepository, booking *model.Booking, status string, by actor, reason string) error {
+	from := booking.Status
+
+	if !isValidBookingStatus(status) {
+		utils.Log.WithField("status", status).Warn("Unknown booking status requested")
+		return constants.ErrInvalidStatusUpdate
+	}
+	if len(bookingTransitions[from]) == 0 {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": booking.BookingID,
+			"status":    from,
+		}).Warn("Cannot update status - booking already in final state")
+		return constants.ErrBookingAlreadyFinal
+	}
+	if !canTransitionBooking(from, status) {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": booking.BookingID,
+			"from":      from,
+			"to":        status,
+		}).Warn("Booking status transition not allowed")
+		return constants.ErrInvalidStatusTransition
+	}
+
+	now := time.Now().In(helpers.GetAppLocation())
+	booking.Status = status
+
+	switch status {
+	case constants.ENUM_STATUS_BOOKING_BOOKED:
+		booking.PaymentVerifiedAt = &now
+	case constants.ENUM_STATUS_BOOKING_CALCEL:
+		booking.CancelledAt = &now
+		booking.CancelReason = reason
+	}
+
+	if err := bookingRepo.UpdateBooking(ctx, tx, *booking); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to update booking status")
+		return constants.ErrUpdateBooking
+	}
+
+	return recordBookingStatus(ctx, tx, bookingRepo, *booking, from, by, reason, now)
+}
+
+// recordBookingStatus appends a history row for a booking that has just entered its
+// current status. from is empty for a newly created booking.
+func recordBookingStatus(ctx context.Context, tx *gorm.DB, bookingRepo repository.IBookingRepository, booking model.Booking, from string, by actor, reason string, changedAt time.Time) error {
+	history := model.BookingStatusHistory{
+		HistoryID:  uuid.New(),
+		BookingID:  booking.BookingID,
+		FromStatus: from,
+		ToStatus:   booking.Status,
+		ActorRole:  by.Role,
+		Reason:     reason,
+		ChangedAt:  changedAt,
+	}
+	if by.UserID != uuid.Nil {
+		userID := by.UserID
+		history.ChangedBy = &userID
+	}
+
+	if err := bookingRepo.CreateBookingStatusHistory(ctx, tx, history); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to record booking status history")
+		return constants.ErrUpdateBooking
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID": booking.BookingID,
+		"from":      from,
+		"to":        booking.Status,
+		"actorRole": by.Role,
+	}).Info("Booking status changed")
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// cancellationTier grants RefundPercent of the paid amount when a booking is cancelled
+// at least MinNotice before it starts.
+type cancellationTier struct {
+	MinNotice     time.Duration
+	RefundPercent int
+}
+
+const defaultCancellationPolicy = "24h:100,3h:50"
+
+// loadCancellationPolicy reads CANCELLATION_POLICY, a comma separated list of
+// "<notice>:<percent>" tiers such as "24h:100,3h:50". Cancelling with less notice than
+// the smallest tier refunds nothing.
+func loadCancellationPolicy() []cancellationTier {
+	value := os.Getenv("CANCELLATION_POLICY")
+	if value == "" {
+		value = defaultCancellationPolicy
+	}
+
+	tiers, err := parseCancellationPolicy(value)
+	if err != nil {
+		utils.Log.WithError(err).WithField("policy", value).Warn("Invalid CANCELLATION_POLICY, using default")
+		tiers, _ = parseCancellationPolicy(defaultCancellationPolicy)
+	}
+
+	return tiers
+}
+
+func parseCancellationPolicy(value string) ([]cancellationTier, error) {
+	var tiers []cancellationTier
+	for _, part := range strings.Split(value, ",") {
+		notice, percent, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("tier %q must look like <notice>:<percent>", part)
+		}
+
+		minNotice, err := time.ParseDuration(notice)
+		if err != nil || minNotice < 0 {
+			return nil, fmt.Errorf("invalid notice in tier %q", part)
+		}
+
+		refundPercent, err := strconv.Atoi(percent)
+		if err != nil || refundPercent < 0 || refundPercent > 100 {
+			return nil, fmt.Errorf("invalid refund percent in tier %q", part)
+		}
+
+		tiers = append(tiers, cancellationTier{MinNotice: minNotice, RefundPercent: refundPercent})
+	}
+
+	// Longest notice first, so the first matching tier is the most generous one.
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinNotice > tiers[j].MinNotice })
+
+	return tiers, nil
+}
+
+func refundPercentFor(tiers []cancellationTier, notice time.Duration) int {
+	for _, tier := range tiers {
+		if notice >= tier.MinNotice {
+			return tier.RefundPercent
+		}
+	}
+
+	return 0
+}
+
+// amountPaid is what the customer has actually paid for a booking. A reschedule moves
+// TotalPayment to the new price and records the difference in PaymentAdjustment until it
+// is settled, so the paid amount is the price before that outstanding difference.
+func amountPaid(booking model.Booking) float64 {
+	paid := roundPrice(booking.TotalPayment - booking.PaymentAdjustment)
+	if paid < 0 {
+		return 0
+	}
+
+	return paid
+}
+
+// cancelBookingWithRefund cancels a booking that has not started yet and records the
+// refund owed under the cancellation policy. Only bookings that have been paid, or have
+// a payment proof waiting for review, earn a refund.
+func cancelBookingWithRefund(ctx context.Context, tx *gorm.DB, bookingRepo repository.IBookingRepository, booking *model.Booking, by actor, reason string, now time.Time) error {
+	if !booking.StartTime.After(now) {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": booking.BookingID,
+			"startTime": booking.StartTime,
+		}).Warn("Cannot cancel booking - booking has already started")
+		return constants.ErrCannotCancelLate
+	}
+
+	refundPercent := 0
+	if booking.Status == constants.ENUM_STATUS_BOOKING_BOOKED || booking.Status == constants.ENUM_STATUS_BOOKING_WAITING {
+		refundPercent = refundPercentFor(loadCancellationPolicy(), booking.StartTime.Sub(now))
+	}
+	booking.RefundAmount = roundPrice(amountPaid(*booking) * float64(refundPercent) / 100)
+
+	utils.Log.WithFields(logrus.Fields{
+		"bookingID":     booking.BookingID,
+		"notice":        booking.StartTime.Sub(now),
+		"amountPaid":    amountPaid(*booking),
+		"refundPercent": refundPercent,
+		"refundAmount":  booking.RefundAmount,
+	}).Info("Applying cancellation policy")
+
+	return applyBookingTransition(ctx, tx, bookingRepo, booking, constants.ENUM_STATUS_BOOKING_CALCEL, by, reason)
+}
@@ -1,17 +1,27 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fieldreserve/constants"
 	"fieldreserve/dto"
 	"fieldreserve/helpers"
 	"fieldreserve/model"
 	"fieldreserve/repository"
+	"fieldreserve/storage"
 	"fieldreserve/utils"
+	"fmt"
+	"mime/multipart"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// fieldImagePath is where PublicRoutes serves field photos from.
+const fieldImagePath = "/assets/fields/"
+
 type (
 	IFieldService interface {
 		CreateField(ctx context.Context, req dto.CreateFieldRequest) (dto.FieldResponse, error)
@@ -19,34 +29,38 @@ type (
 		GetFieldByID(ctx context.Context, fieldID string) (dto.FieldFullResponse, error)
 		UpdateField(ctx context.Context, req dto.UpdateFieldRequest) (dto.FieldResponse, error)
 		DeleteField(ctx context.Context, req dto.DeleteFieldRequest) (dto.FieldResponse, error)
+		AddFieldImage(ctx context.Context, req dto.AddFieldImageRequest) (dto.FieldImageResponse, error)
+		ReorderFieldImages(ctx context.Context, req dto.ReorderFieldImagesRequest) ([]dto.FieldImageResponse, error)
+		DeleteFieldImage(ctx context.Context, req dto.DeleteFieldImageRequest) (dto.FieldImageResponse, error)
 	}
 
 	FieldService struct {
 		fieldRepo repository.IFieldRepository
+		store     storage.Storage
 	}
 )
 
-func NewFieldService(fieldRepo repository.IFieldRepository) *FieldService {
+func NewFieldService(fieldRepo repository.IFieldRepository, store storage.Storage) *FieldService {
 	return &FieldService{
 		fieldRepo: fieldRepo,
+		store:     store,
 	}
 }
 
 func (fs *FieldService) CreateField(ctx context.Context, req dto.CreateFieldRequest) (dto.FieldResponse, error) {
 	utils.Log.Info("Creating new field")
 
-	imageName, err := helpers.SaveImage(req.FieldImage, "./assets/fields", "field")
-	if err != nil {
-		utils.Log.Errorf("Failed to save image: %v", err)
-		return dto.FieldResponse{}, constants.ErrSaveImages
-	}
-
 	categoryUUID, err := uuid.Parse(req.CategoryID)
 	if err != nil {
 		utils.Log.Errorf("Invalid category UUID: %v", err)
 		return dto.FieldResponse{}, constants.ErrInvalidUUID
 	}
 
+	imageName, err := fs.saveFieldImage(ctx, req.FieldImage)
+	if err != nil {
+		return dto.FieldResponse{}, err
+	}
+
 	field := model.Field{
 		FieldID:      uuid.New(),
 		CategoryID:   categoryUUID,
@@ -56,8 +70,22 @@ func (fs *FieldService) CreateField(ctx context.Context, req dto.CreateFieldRequ
 		FieldImage:   imageName,
 	}
 
-	if err := fs.fieldRepo.CreateField(ctx, nil, field); err != nil {
+	cover := model.FieldImage{
+		ImageID:  uuid.New(),
+		FieldID:  field.FieldID,
+		FileName: imageName,
+		IsCover:  true,
+	}
+
+	err = fs.fieldRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := fs.fieldRepo.CreateField(ctx, tx, field); err != nil {
+			return err
+		}
+		return fs.fieldRepo.CreateFieldImage(ctx, tx, cover)
+	})
+	if err != nil {
 		utils.Log.Errorf("Failed to create field in repository: %v", err)
+		fs.removeFieldImage(ctx, imageName)
 		return dto.FieldResponse{}, err
 	}
 
@@ -69,6 +97,7 @@ func (fs *FieldService) CreateField(ctx context.Context, req dto.CreateFieldRequ
 		FieldAddress: field.FieldAddress,
 		FieldPrice:   field.FieldPrice,
 		FieldImage:   field.FieldImage,
+		ImageURLs:    fieldImageURLs(field.FieldImage),
 		CategoryID:   field.CategoryID,
 	}, nil
 }
@@ -92,6 +121,7 @@ func (fs *FieldService) GetAllFieldWithPagination(ctx context.Context, req dto.F
 			FieldAddress: field.FieldAddress,
 			FieldPrice:   field.FieldPrice,
 			FieldImage:   field.FieldImage,
+			ImageURLs:    fieldImageURLs(field.FieldImage),
 			CategoryID:   field.CategoryID,
 		}
 
@@ -123,6 +153,12 @@ func (fs *FieldService) GetFieldByID(ctx context.Context, fieldID string) (dto.F
 		return dto.FieldFullResponse{}, constants.ErrGetFieldByID
 	}
 
+	images, err := fs.fieldRepo.GetFieldImages(ctx, nil, field.FieldID)
+	if err != nil {
+		utils.Log.Errorf("Failed to fetch field images: %v", err)
+		return dto.FieldFullResponse{}, constants.ErrGetFieldByID
+	}
+
 	utils.Log.Infof("Field fetched successfully: %s", fieldID)
 
 	res := dto.FieldFullResponse{
@@ -131,6 +167,8 @@ func (fs *FieldService) GetFieldByID(ctx context.Context, fieldID string) (dto.F
 		FieldAddress: field.FieldAddress,
 		FieldPrice:   field.FieldPrice,
 		FieldImage:   field.FieldImage,
+		ImageURLs:    fieldImageURLs(field.FieldImage),
+		Images:       toFieldImageResponses(images),
 		Category: dto.CategoryCompactResponse{
 			CategoryID:  field.Category.CategoryID,
 			Name:        field.Category.Name,
@@ -169,20 +207,36 @@ func (fs *FieldService) UpdateField(ctx context.Context, req dto.UpdateFieldRequ
 		field.FieldPrice = req.FieldPrice
 	}
 
+	previousImage := field.FieldImage
 	if req.FieldImage != nil {
-		imageName, err := helpers.SaveImage(req.FieldImage, "./assets/fields", "field")
+		imageName, err := fs.saveFieldImage(ctx, req.FieldImage)
 		if err != nil {
-			utils.Log.Errorf("Failed to save new image: %v", err)
-			return dto.FieldResponse{}, constants.ErrSaveImages
+			return dto.FieldResponse{}, err
 		}
 		field.FieldImage = imageName
 	}
 
-	if err := fs.fieldRepo.UpdateField(ctx, nil, field); err != nil {
+	err = fs.fieldRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := fs.fieldRepo.UpdateField(ctx, tx, field); err != nil {
+			return err
+		}
+		if field.FieldImage == previousImage {
+			return nil
+		}
+		return fs.replaceCoverImage(ctx, tx, field.FieldID, previousImage, field.FieldImage)
+	})
+	if err != nil {
 		utils.Log.Errorf("Failed to update field: %v", err)
+		if field.FieldImage != previousImage {
+			fs.removeFieldImage(ctx, field.FieldImage)
+		}
 		return dto.FieldResponse{}, constants.ErrUpdateField
 	}
 
+	if field.FieldImage != previousImage {
+		fs.removeFieldImage(ctx, previousImage)
+	}
+
 	utils.Log.Infof("Field updated successfully: %s", req.FieldID)
 
 	res := dto.FieldResponse{
@@ -191,6 +245,7 @@ func (fs *FieldService) UpdateField(ctx context.Context, req dto.UpdateFieldRequ
 		FieldAddress: field.FieldAddress,
 		FieldPrice:   field.FieldPrice,
 		FieldImage:   field.FieldImage,
+		ImageURLs:    fieldImageURLs(field.FieldImage),
 		CategoryID:   field.CategoryID,
 	}
 
@@ -225,8 +280,331 @@ func (fs *FieldService) DeleteField(ctx context.Context, req dto.DeleteFieldRequ
 		FieldAddress: deletedField.FieldAddress,
 		FieldPrice:   deletedField.FieldPrice,
 		FieldImage:   deletedField.FieldImage,
+		ImageURLs:    fieldImageURLs(deletedField.FieldImage),
 		CategoryID:   deletedField.CategoryID,
 	}
 
 	return res, nil
 }
+
+// AddFieldImage appends a photo to the gallery. The first photo of a field, or one
+// flagged as cover, becomes the cover.
+func (fs *FieldService) AddFieldImage(ctx context.Context, req dto.AddFieldImageRequest) (dto.FieldImageResponse, error) {
+	utils.Log.Infof("Adding image to field: %s", req.FieldID)
+
+	if _, err := uuid.Parse(req.FieldID); err != nil {
+		utils.Log.Errorf("Invalid field UUID: %v", err)
+		return dto.FieldImageResponse{}, constants.ErrInvalidUUID
+	}
+
+	field, _, err := fs.fieldRepo.GetFieldByID(ctx, nil, req.FieldID)
+	if err != nil {
+		utils.Log.Errorf("Field not found: %v", err)
+		return dto.FieldImageResponse{}, constants.ErrFieldNotFound
+	}
+
+	imageName, err := fs.saveFieldImage(ctx, req.Image)
+	if err != nil {
+		return dto.FieldImageResponse{}, err
+	}
+
+	image := model.FieldImage{
+		ImageID:  uuid.New(),
+		FieldID:  field.FieldID,
+		FileName: imageName,
+		Caption:  req.Caption,
+		IsCover:  req.IsCover,
+	}
+
+	err = fs.fieldRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		images, err := fs.fieldRepo.GetFieldImages(ctx, tx, field.FieldID)
+		if err != nil {
+			return err
+		}
+
+		hasCover := false
+		for _, existing := range images {
+			if existing.SortOrder >= image.SortOrder {
+				image.SortOrder = existing.SortOrder + 1
+			}
+			hasCover = hasCover || existing.IsCover
+		}
+		if !hasCover {
+			image.IsCover = true
+		}
+
+		if err := fs.fieldRepo.CreateFieldImage(ctx, tx, image); err != nil {
+			return err
+		}
+		if image.IsCover {
+			return fs.fieldRepo.SetFieldCover(ctx, tx, field.FieldID, image.ImageID, image.FileName)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.Log.Errorf("Failed to add field image: %v", err)
+		fs.removeFieldImage(ctx, imageName)
+		return dto.FieldImageResponse{}, constants.ErrSaveImages
+	}
+
+	utils.Log.Infof("Image %s added to field %s", image.ImageID, req.FieldID)
+
+	return toFieldImageResponse(image), nil
+}
+
+// ReorderFieldImages rewrites the sort order of the whole gallery and optionally moves the
+// cover. Partial lists are rejected so no image is left with a stale position.
+func (fs *FieldService) ReorderFieldImages(ctx context.Context, req dto.ReorderFieldImagesRequest) ([]dto.FieldImageResponse, error) {
+	utils.Log.Infof("Reordering images of field: %s", req.FieldID)
+
+	fieldID, err := uuid.Parse(req.FieldID)
+	if err != nil {
+		utils.Log.Errorf("Invalid field UUID: %v", err)
+		return nil, constants.ErrInvalidUUID
+	}
+
+	images, err := fs.fieldRepo.GetFieldImages(ctx, nil, fieldID)
+	if err != nil {
+		utils.Log.Errorf("Failed to fetch field images: %v", err)
+		return nil, constants.ErrGetFieldByID
+	}
+
+	byID := make(map[uuid.UUID]model.FieldImage, len(images))
+	for _, image := range images {
+		byID[image.ImageID] = image
+	}
+
+	if len(req.ImageIDs) != len(images) {
+		utils.Log.Warnf("Reorder of field %s lists %d of %d images", req.FieldID, len(req.ImageIDs), len(images))
+		return nil, constants.ErrInvalidImageOrder
+	}
+
+	ordered := make([]model.FieldImage, 0, len(req.ImageIDs))
+	seen := make(map[uuid.UUID]bool, len(req.ImageIDs))
+	for _, rawID := range req.ImageIDs {
+		imageID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, constants.ErrInvalidUUID
+		}
+		image, ok := byID[imageID]
+		if !ok || seen[imageID] {
+			utils.Log.Warnf("Reorder of field %s has unknown or repeated image %s", req.FieldID, rawID)
+			return nil, constants.ErrInvalidImageOrder
+		}
+		seen[imageID] = true
+		ordered = append(ordered, image)
+	}
+
+	var cover *model.FieldImage
+	if req.CoverImageID != "" {
+		coverID, err := uuid.Parse(req.CoverImageID)
+		if err != nil {
+			return nil, constants.ErrInvalidUUID
+		}
+		image, ok := byID[coverID]
+		if !ok {
+			return nil, constants.ErrFieldImageNotFound
+		}
+		cover = &image
+	}
+
+	err = fs.fieldRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		for i := range ordered {
+			if err := fs.fieldRepo.UpdateFieldImageOrder(ctx, tx, ordered[i].ImageID, i); err != nil {
+				return err
+			}
+			ordered[i].SortOrder = i
+		}
+		if cover != nil {
+			return fs.fieldRepo.SetFieldCover(ctx, tx, fieldID, cover.ImageID, cover.FileName)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.Log.Errorf("Failed to reorder field images: %v", err)
+		return nil, constants.ErrUpdateField
+	}
+
+	if cover != nil {
+		for i := range ordered {
+			ordered[i].IsCover = ordered[i].ImageID == cover.ImageID
+		}
+	}
+
+	utils.Log.Infof("Images of field %s reordered", req.FieldID)
+
+	return toFieldImageResponses(ordered), nil
+}
+
+// DeleteFieldImage removes a photo from the gallery. When the cover goes, the next image
+// in order takes its place.
+func (fs *FieldService) DeleteFieldImage(ctx context.Context, req dto.DeleteFieldImageRequest) (dto.FieldImageResponse, error) {
+	utils.Log.Infof("Deleting image %s of field %s", req.ImageID, req.FieldID)
+
+	if _, err := uuid.Parse(req.FieldID); err != nil {
+		utils.Log.Errorf("Invalid field UUID: %v", err)
+		return dto.FieldImageResponse{}, constants.ErrInvalidUUID
+	}
+	if _, err := uuid.Parse(req.ImageID); err != nil {
+		utils.Log.Errorf("Invalid image UUID: %v", err)
+		return dto.FieldImageResponse{}, constants.ErrInvalidUUID
+	}
+
+	image, _, err := fs.fieldRepo.GetFieldImageByID(ctx, nil, req.FieldID, req.ImageID)
+	if err != nil {
+		utils.Log.Errorf("Field image not found: %v", err)
+		return dto.FieldImageResponse{}, constants.ErrFieldImageNotFound
+	}
+
+	err = fs.fieldRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := fs.fieldRepo.DeleteFieldImage(ctx, tx, image.ImageID); err != nil {
+			return err
+		}
+		if !image.IsCover {
+			return nil
+		}
+
+		remaining, err := fs.fieldRepo.GetFieldImages(ctx, tx, image.FieldID)
+		if err != nil {
+			return err
+		}
+		if len(remaining) == 0 {
+			return fs.fieldRepo.SetFieldCover(ctx, tx, image.FieldID, uuid.Nil, "")
+		}
+		return fs.fieldRepo.SetFieldCover(ctx, tx, image.FieldID, remaining[0].ImageID, remaining[0].FileName)
+	})
+	if err != nil {
+		utils.Log.Errorf("Failed to delete field image: %v", err)
+		return dto.FieldImageResponse{}, constants.ErrUpdateField
+	}
+
+	fs.removeFieldImage(ctx, image.FileName)
+
+	utils.Log.Infof("Image %s of field %s deleted", req.ImageID, req.FieldID)
+
+	return toFieldImageResponse(image), nil
+}
+
+// replaceCoverImage swaps the cover row for a newly uploaded photo, keeping its position,
+// so UpdateField still replaces the main photo rather than growing the gallery.
+func (fs *FieldService) replaceCoverImage(ctx context.Context, tx *gorm.DB, fieldID uuid.UUID, previousImage, imageName string) error {
+	images, err := fs.fieldRepo.GetFieldImages(ctx, tx, fieldID)
+	if err != nil {
+		return err
+	}
+
+	cover := model.FieldImage{
+		ImageID:  uuid.New(),
+		FieldID:  fieldID,
+		FileName: imageName,
+		IsCover:  true,
+	}
+	for _, image := range images {
+		if image.IsCover && image.FileName == previousImage {
+			if err := fs.fieldRepo.DeleteFieldImage(ctx, tx, image.ImageID); err != nil {
+				return err
+			}
+			cover.SortOrder = image.SortOrder
+			cover.Caption = image.Caption
+		}
+	}
+
+	if err := fs.fieldRepo.CreateFieldImage(ctx, tx, cover); err != nil {
+		return err
+	}
+	return fs.fieldRepo.SetFieldCover(ctx, tx, fieldID, cover.ImageID, cover.FileName)
+}
+
+// saveFieldImage validates an uploaded photo and stores the re-encoded original along
+// with every resized variant. Only the original's name is kept on the field.
+func (fs *FieldService) saveFieldImage(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	processed, err := helpers.ProcessImage(file, helpers.FieldImageVariants)
+	if errors.Is(err, helpers.ErrUnsupportedImage) {
+		utils.Log.Warnf("Rejected field image %s: %v", file.Filename, err)
+		return "", constants.ErrInvalidImage
+	}
+	if errors.Is(err, helpers.ErrImageTooLarge) {
+		utils.Log.Warnf("Rejected field image %s: %v", file.Filename, err)
+		return "", constants.ErrImageTooLarge
+	}
+	if err != nil {
+		utils.Log.Errorf("Failed to process image: %v", err)
+		return "", constants.ErrSaveImages
+	}
+
+	imageName := fmt.Sprintf("field_%d%s", time.Now().UnixNano(), processed.Extension)
+	files := map[string][]byte{imageName: processed.Original}
+	for variant, data := range processed.Variants {
+		files[helpers.ImageVariantName(imageName, variant)] = data
+	}
+
+	for name, data := range files {
+		key, err := storage.Key(storage.FolderFields, name)
+		if err == nil {
+			err = fs.store.Put(ctx, key, bytes.NewReader(data), processed.ContentType)
+		}
+		if err != nil {
+			utils.Log.Errorf("Failed to save image %s: %v", name, err)
+			fs.removeFieldImage(ctx, imageName)
+			return "", constants.ErrSaveImages
+		}
+	}
+
+	return imageName, nil
+}
+
+// removeFieldImage deletes an image, and its resized variants, once no field references it.
+func (fs *FieldService) removeFieldImage(ctx context.Context, imageName string) {
+	if imageName == "" {
+		return
+	}
+
+	names := []string{imageName}
+	for _, variant := range helpers.FieldImageVariants {
+		names = append(names, helpers.ImageVariantName(imageName, variant.Name))
+	}
+
+	for _, name := range names {
+		key, err := storage.Key(storage.FolderFields, name)
+		if err == nil {
+			err = fs.store.Delete(ctx, key)
+		}
+		if err != nil {
+			utils.Log.Warnf("Failed to remove field image %s: %v", name, err)
+		}
+	}
+}
+
+// fieldImageURLs lists where each size of a field photo is served. Images uploaded before
+// variants existed still resolve, as the file route falls back to the original.
+func fieldImageURLs(imageName string) *dto.FieldImageURLs {
+	if imageName == "" {
+		return nil
+	}
+
+	return &dto.FieldImageURLs{
+		Original: fieldImagePath + imageName,
+		Thumb:    fieldImagePath + helpers.ImageVariantName(imageName, "thumb"),
+		Medium:   fieldImagePath + helpers.ImageVariantName(imageName, "medium"),
+		Large:    fieldImagePath + helpers.ImageVariantName(imageName, "large"),
+	}
+}
+
+func toFieldImageResponse(image model.FieldImage) dto.FieldImageResponse {
+	return dto.FieldImageResponse{
+		ImageID:   image.ImageID,
+		FileName:  image.FileName,
+		Caption:   image.Caption,
+		SortOrder: image.SortOrder,
+		IsCover:   image.IsCover,
+		URLs:      fieldImageURLs(image.FileName),
+	}
+}
+
+func toFieldImageResponses(images []model.FieldImage) []dto.FieldImageResponse {
+	res := make([]dto.FieldImageResponse, 0, len(images))
+	for _, image := range images {
+		res = append(res, toFieldImageResponse(image))
+	}
+	return res
+}
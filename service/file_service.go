@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/storage"
+	"fieldreserve/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type (
+	IFileService interface {
+		GetFieldImage(ctx context.Context, name string) (storage.Object, error)
+		GetSignedFile(ctx context.Context, req dto.SignedFileRequest) (storage.Object, error)
+	}
+
+	FileService struct {
+		store storage.Storage
+	}
+)
+
+func NewFileService(store storage.Storage) *FileService {
+	return &FileService{
+		store: store,
+	}
+}
+
+// GetFieldImage reads a public field photo from the configured storage backend. Photos
+// uploaded before resized variants were generated only have the original, which is
+// served in place of a missing variant.
+func (fs *FileService) GetFieldImage(ctx context.Context, name string) (storage.Object, error) {
+	key, err := storage.Key(storage.FolderFields, name)
+	if err != nil {
+		return storage.Object{}, constants.ErrFileNotFound
+	}
+
+	object, err := fs.get(ctx, key)
+	if errors.Is(err, constants.ErrFileNotFound) {
+		if original, ok := helpers.ImageVariantSource(name, helpers.FieldImageVariants); ok {
+			return fs.GetFieldImage(ctx, original)
+		}
+	}
+
+	return object, err
+}
+
+// GetSignedFile serves links signed by the local storage backend. Other backends hand
+// out links to the bucket itself and never reach this.
+func (fs *FileService) GetSignedFile(ctx context.Context, req dto.SignedFileRequest) (storage.Object, error) {
+	path := storage.LocalSignedPathPrefix + req.Key
+	if err := helpers.VerifySignedURL(path, req.Expires, req.Signature, helpers.SignedURLSecret(), time.Now()); err != nil {
+		utils.Log.WithError(err).WithField("key", req.Key).Warn("Rejected signed file link")
+		return storage.Object{}, constants.ErrInvalidSignedURL
+	}
+
+	return fs.get(ctx, req.Key)
+}
+
+func (fs *FileService) get(ctx context.Context, key string) (storage.Object, error) {
+	object, err := fs.store.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return storage.Object{}, constants.ErrFileNotFound
+	}
+	if err != nil {
+		utils.Log.WithError(err).WithFields(logrus.Fields{"key": key}).Error("Failed to read file from storage")
+		return storage.Object{}, constants.ErrReadFile
+	}
+
+	return object, nil
+}
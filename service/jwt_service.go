@@ -1,33 +1,56 @@
 package service
 
 import (
+	"context"
 	"fieldreserve/constants"
+	"fieldreserve/revocation"
 	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type (
 	InterfaceJWTService interface {
-		GenerateToken(userID string, role string) (string, string, error)
+		GenerateToken(userID string, role string, sessionID string) (string, string, error)
 		ValidateToken(token string) (*jwt.Token, error)
 		GetUserIDByToken(tokenString string) (string, error)
 		GetRoleByToken(tokenString string) (string, error)
+		ParseToken(tokenString string, tokenType string) (TokenClaims, error)
+		ValidateAccessToken(ctx context.Context, tokenString string) (TokenClaims, error)
+		RevokeSession(ctx context.Context, sessionID uuid.UUID) error
 	}
 
 	jwtCustomClaims struct {
-		UserID string `json:"user_id"`
-		Role   string `json:"role"`
+		UserID    string `json:"user_id"`
+		Role      string `json:"role"`
+		TokenType string `json:"typ"`
+		SessionID string `json:"sid"`
 		jwt.RegisteredClaims
 	}
 
+	// TokenClaims is what the rest of the app needs from a verified token.
+	TokenClaims struct {
+		UserID    string
+		Role      string
+		TokenID   uuid.UUID
+		SessionID uuid.UUID
+		ExpiresAt time.Time
+	}
+
 	JWTService struct {
-		secretKey string
-		issuer    string
+		secretKey   string
+		issuer      string
+		revocations revocation.Store
 	}
 )
 
+const (
+	accessTokenTTL  = 5 * time.Minute
+	refreshTokenTTL = 7 * 24 * time.Hour
+)
+
 func getSecretKey() string {
 	secretKey := os.Getenv("JWT_SECRET")
 	if secretKey == "" {
@@ -37,20 +60,25 @@ func getSecretKey() string {
 	return secretKey
 }
 
-func NewJWTService() *JWTService {
+func NewJWTService(revocations revocation.Store) *JWTService {
 	return &JWTService{
-		secretKey: getSecretKey(),
-		issuer:    "Template",
+		secretKey:   getSecretKey(),
+		issuer:      "Template",
+		revocations: revocations,
 	}
 }
 
 
-func (j *JWTService) GenerateToken(userID string, role string) (string, string, error) {
+// GenerateToken issues an access and refresh token for the session (token family) sessionID.
+func (j *JWTService) GenerateToken(userID string, role string, sessionID string) (string, string, error) {
 	accessClaims := jwtCustomClaims{
 		userID,
 		role,
+		constants.TOKEN_TYPE_ACCESS,
+		sessionID,
 		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Second * 300)),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(accessTokenTTL)),
 			Issuer:    j.issuer,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -65,8 +93,11 @@ func (j *JWTService) GenerateToken(userID string, role string) (string, string,
 	refreshClaims := jwtCustomClaims{
 		userID,
 		role,
+		constants.TOKEN_TYPE_REFRESH,
+		sessionID,
 		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Second * 3600 * 24 * 7)),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(refreshTokenTTL)),
 			Issuer:    j.issuer,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -119,4 +150,62 @@ func (j *JWTService) GetRoleByToken(tokenString string) (string, error) {
 	}
 
 	return claims.Role, nil
-}
\ No newline at end of file
+}
+
+// ParseToken verifies tokenString and checks that it was issued as tokenType, so a refresh
+// token cannot be presented where an access token is expected and vice versa.
+func (j *JWTService) ParseToken(tokenString string, tokenType string) (TokenClaims, error) {
+	token, err := j.ValidateToken(tokenString)
+	if err != nil {
+		return TokenClaims{}, constants.ErrValidateToken
+	}
+
+	claims, ok := token.Claims.(*jwtCustomClaims)
+	if !ok || !token.Valid || claims.TokenType != tokenType {
+		return TokenClaims{}, constants.ErrTokenInvalid
+	}
+
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil || claims.ExpiresAt == nil {
+		return TokenClaims{}, constants.ErrTokenInvalid
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return TokenClaims{}, constants.ErrTokenInvalid
+	}
+
+	return TokenClaims{
+		UserID:    claims.UserID,
+		Role:      claims.Role,
+		TokenID:   tokenID,
+		SessionID: sessionID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// ValidateAccessToken is ParseToken for access tokens plus a revocation check on the
+// token's jti and session, which is what authenticates a request.
+func (j *JWTService) ValidateAccessToken(ctx context.Context, tokenString string) (TokenClaims, error) {
+	claims, err := j.ParseToken(tokenString, constants.TOKEN_TYPE_ACCESS)
+	if err != nil {
+		return TokenClaims{}, err
+	}
+
+	revoked, err := j.revocations.IsRevoked(ctx, claims.TokenID, claims.SessionID)
+	if err != nil {
+		return TokenClaims{}, constants.ErrCheckTokenRevocation
+	}
+	if revoked {
+		return TokenClaims{}, constants.ErrTokenRevoked
+	}
+
+	return claims, nil
+}
+
+// RevokeSession rejects every access token already issued for the session. Refresh
+// tokens are stopped by revoking the token family, so the entry only has to outlive the
+// access tokens.
+func (j *JWTService) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	return j.revocations.Revoke(ctx, sessionID, time.Now().Add(accessTokenTTL))
+}
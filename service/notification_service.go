@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/mailer"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type (
+	INotificationService interface {
+		Notify(ctx context.Context, userID uuid.UUID, subject, message string) error
+	}
+
+	// MailNotificationService emails notifications to the address on the user's account.
+	MailNotificationService struct {
+		userRepo repository.IUserRepository
+		mailer   mailer.Mailer
+	}
+
+	// LogNotificationService only writes notifications to the application log, for setups
+	// such as tests where nothing should be delivered.
+	LogNotificationService struct{}
+)
+
+func NewMailNotificationService(userRepo repository.IUserRepository, mail mailer.Mailer) *MailNotificationService {
+	return &MailNotificationService{
+		userRepo: userRepo,
+		mailer:   mail,
+	}
+}
+
+func (ns *MailNotificationService) Notify(ctx context.Context, userID uuid.UUID, subject, message string) error {
+	user, _, err := ns.userRepo.GetUserByID(ctx, nil, userID.String())
+	if err != nil {
+		return fmt.Errorf("get user to notify: %w", err)
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\n%s", user.Name, message)
+	if err := ns.mailer.Send(ctx, mailer.Message{To: user.Email, Subject: subject, Body: body}); err != nil {
+		return fmt.Errorf("send notification email: %w", err)
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"userID":  userID,
+		"subject": subject,
+	}).Info("Notification sent")
+
+	return nil
+}
+
+func NewLogNotificationService() *LogNotificationService {
+	return &LogNotificationService{}
+}
+
+func (ns *LogNotificationService) Notify(ctx context.Context, userID uuid.UUID, subject, message string) error {
+	utils.Log.WithFields(logrus.Fields{
+		"userID":  userID,
+		"subject": subject,
+	}).Info(message)
+
+	return nil
+}
@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/model"
+	"fieldreserve/payment"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type (
+	IPaymentService interface {
+		CreatePayment(ctx context.Context, req dto.CreatePaymentRequest) (dto.PaymentResponse, error)
+		GetBookingPayments(ctx context.Context, bookingID string) ([]dto.PaymentResponse, error)
+		HandleWebhook(ctx context.Context, req dto.PaymentWebhookRequest) error
+		SimulatePayment(ctx context.Context, req dto.SimulatePaymentRequest) (dto.PaymentResponse, error)
+		RefundPayment(ctx context.Context, req dto.RefundPaymentRequest) (dto.PaymentResponse, error)
+	}
+
+	PaymentService struct {
+		paymentRepo     repository.IPaymentRepository
+		bookingRepo     repository.IBookingRepository
+		provider        payment.PaymentProvider
+		jwtService      InterfaceJWTService
+		waitlistService IWaitlistService
+	}
+)
+
+func NewPaymentService(
+	paymentRepo repository.IPaymentRepository,
+	bookingRepo repository.IBookingRepository,
+	provider payment.PaymentProvider,
+	jwtService InterfaceJWTService,
+	waitlistService IWaitlistService,
+) *PaymentService {
+	return &PaymentService{
+		paymentRepo:     paymentRepo,
+		bookingRepo:     bookingRepo,
+		provider:        provider,
+		jwtService:      jwtService,
+		waitlistService: waitlistService,
+	}
+}
+
+// CreatePayment opens a charge with the configured provider for an unpaid booking. An
+// open charge for the same amount is reused so retries do not pile up charges.
+func (ps *PaymentService) CreatePayment(ctx context.Context, req dto.CreatePaymentRequest) (dto.PaymentResponse, error) {
+	utils.Log.WithField("bookingID", req.BookingID).Info("Creating payment for booking")
+
+	user, err := actorFromContext(ctx, ps.jwtService)
+	if err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	if _, err := uuid.Parse(req.BookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Invalid booking ID format")
+		return dto.PaymentResponse{}, constants.ErrInvalidUUID
+	}
+
+	booking, _, err := ps.bookingRepo.GetBookingByID(ctx, nil, req.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Booking not found")
+		return dto.PaymentResponse{}, constants.ErrBookingNotFound
+	}
+
+	if err := authorize(user, booking.UserID, ownerOnly, "pay for booking", logrus.Fields{"bookingID": req.BookingID}); err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	if booking.Status != constants.ENUM_STATUS_BOOKING_PENDING {
+		utils.Log.WithFields(logrus.Fields{
+			"bookingID": req.BookingID,
+			"status":    booking.Status,
+		}).Warn("Booking is not awaiting payment")
+		return dto.PaymentResponse{}, constants.ErrPaymentNotAllowed
+	}
+
+	payments, err := ps.paymentRepo.GetPaymentsByBookingID(ctx, nil, booking.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Failed to get booking payments")
+		return dto.PaymentResponse{}, constants.ErrCreatePayment
+	}
+	for _, existing := range payments {
+		if existing.Status == payment.StatusPending && existing.Provider == ps.provider.Name() && existing.Amount == booking.TotalPayment {
+			utils.Log.WithField("paymentID", existing.PaymentID).Info("Reusing open payment for booking")
+			return toPaymentResponse(existing), nil
+		}
+	}
+
+	charge, err := ps.provider.CreateCharge(ctx, payment.ChargeRequest{
+		OrderID:       booking.BookingID.String(),
+		Amount:        booking.TotalPayment,
+		Description:   fmt.Sprintf("Booking %s %s", booking.Field.FieldName, booking.StartTime.Format("2006-01-02 15:04")),
+		CustomerName:  booking.User.Name,
+		CustomerEmail: booking.User.Email,
+		ExpiresAt:     booking.PaymentDueAt,
+	})
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Payment provider failed to create charge")
+		return dto.PaymentResponse{}, constants.ErrCreatePayment
+	}
+
+	p := model.Payment{
+		PaymentID:   uuid.New(),
+		BookingID:   booking.BookingID,
+		Provider:    ps.provider.Name(),
+		ProviderRef: charge.ProviderRef,
+		Amount:      booking.TotalPayment,
+		Status:      charge.Status,
+		PaymentURL:  charge.PaymentURL,
+		ExpiresAt:   charge.ExpiresAt,
+	}
+	if err := ps.paymentRepo.CreatePayment(ctx, nil, p); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", req.BookingID).Error("Failed to save payment")
+		return dto.PaymentResponse{}, constants.ErrCreatePayment
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"paymentID":   p.PaymentID,
+		"bookingID":   booking.BookingID,
+		"provider":    p.Provider,
+		"providerRef": p.ProviderRef,
+	}).Info("Payment created successfully")
+
+	return toPaymentResponse(p), nil
+}
+
+func (ps *PaymentService) GetBookingPayments(ctx context.Context, bookingID string) ([]dto.PaymentResponse, error) {
+	user, err := actorFromContext(ctx, ps.jwtService)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Invalid booking ID format")
+		return nil, constants.ErrInvalidUUID
+	}
+
+	booking, _, err := ps.bookingRepo.GetBookingByID(ctx, nil, bookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Booking not found")
+		return nil, constants.ErrBookingNotFound
+	}
+
+	if err := authorize(user, booking.UserID, ownerOrAdmin, "view booking payments", logrus.Fields{"bookingID": bookingID}); err != nil {
+		return nil, err
+	}
+
+	payments, err := ps.paymentRepo.GetPaymentsByBookingID(ctx, nil, booking.BookingID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to get booking payments")
+		return nil, constants.ErrGetPayment
+	}
+
+	res := []dto.PaymentResponse{}
+	for _, p := range payments {
+		res = append(res, toPaymentResponse(p))
+	}
+
+	return res, nil
+}
+
+// HandleWebhook applies a provider callback. Deliveries are idempotent: the payment row
+// is locked and an event repeating the current status is ignored. A successful payment
+// moves the booking to booked when it covers the booking's current total.
+func (ps *PaymentService) HandleWebhook(ctx context.Context, req dto.PaymentWebhookRequest) error {
+	if req.Provider != ps.provider.Name() {
+		utils.Log.WithField("provider", req.Provider).Warn("Webhook received for unknown payment provider")
+		return constants.ErrUnknownPaymentProvider
+	}
+
+	event, err := ps.provider.VerifyWebhook(req.Payload, req.Signature)
+	if err != nil {
+		utils.Log.WithError(err).WithField("provider", req.Provider).Warn("Rejected payment webhook")
+		return constants.ErrInvalidWebhookSignature
+	}
+
+	logFields := logrus.Fields{
+		"provider":    req.Provider,
+		"providerRef": event.ProviderRef,
+		"status":      event.Status,
+	}
+	utils.Log.WithFields(logFields).Info("Processing payment webhook")
+
+	err = ps.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		p, _, err := ps.paymentRepo.GetPaymentByProviderRef(ctx, tx, req.Provider, event.ProviderRef)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Log.WithFields(logFields).Warn("Webhook for unknown payment")
+			return constants.ErrPaymentNotFound
+		}
+		if err != nil {
+			utils.Log.WithError(err).WithFields(logFields).Error("Failed to get payment")
+			return constants.ErrGetPayment
+		}
+
+		if p.Status == event.Status {
+			utils.Log.WithFields(logFields).Info("Duplicate payment webhook ignored")
+			return nil
+		}
+		if p.Status != payment.StatusPending {
+			utils.Log.WithFields(logFields).WithField("currentStatus", p.Status).Warn("Webhook for settled payment ignored")
+			return nil
+		}
+
+		switch event.Status {
+		case payment.StatusPaid:
+			if math.Abs(event.Amount-p.Amount) > 1 {
+				utils.Log.WithFields(logFields).WithFields(logrus.Fields{
+					"expected": p.Amount,
+					"received": event.Amount,
+				}).Error("Payment amount does not match charge")
+				return constants.ErrPaymentAmountMismatch
+			}
+
+			paidAt := time.Now().In(helpers.GetAppLocation())
+			p.Status = payment.StatusPaid
+			p.PaidAt = &paidAt
+		case payment.StatusFailed, payment.StatusExpired:
+			// The booking is left to the expiry worker, the customer may still retry.
+			p.Status = event.Status
+		default:
+			utils.Log.WithFields(logFields).Warn("Unsupported payment webhook status")
+			return constants.ErrInvalidWebhookPayload
+		}
+
+		if err := ps.paymentRepo.UpdatePayment(ctx, tx, p); err != nil {
+			utils.Log.WithError(err).WithFields(logFields).Error("Failed to update payment")
+			return constants.ErrUpdatePayment
+		}
+
+		if p.Status != payment.StatusPaid {
+			return nil
+		}
+
+		booking, _, err := ps.bookingRepo.GetBookingByID(ctx, tx, p.BookingID.String())
+		if err != nil {
+			utils.Log.WithError(err).WithField("bookingID", p.BookingID).Error("Booking not found for payment")
+			return constants.ErrBookingNotFound
+		}
+
+		if !canTransitionBooking(booking.Status, constants.ENUM_STATUS_BOOKING_BOOKED) {
+			// Paid after the booking expired or was cancelled; an admin has to refund it.
+			utils.Log.WithFields(logFields).WithFields(logrus.Fields{
+				"bookingID":     booking.BookingID,
+				"bookingStatus": booking.Status,
+			}).Warn("Payment received for booking that can no longer be confirmed")
+			return nil
+		}
+
+		// A charge keeps the amount it was created with, but a reschedule can change the
+		// total of a pending booking. Paying an outdated charge must not confirm the booking.
+		if math.Abs(p.Amount-booking.TotalPayment) > 1 {
+			utils.Log.WithFields(logFields).WithFields(logrus.Fields{
+				"bookingID":    booking.BookingID,
+				"chargeAmount": p.Amount,
+				"bookingTotal": booking.TotalPayment,
+			}).Warn("Payment received for outdated charge; booking left pending for an admin to reconcile")
+			return nil
+		}
+
+		reason := fmt.Sprintf("payment received via %s", p.Provider)
+		return applyBookingTransition(ctx, tx, ps.bookingRepo, &booking, constants.ENUM_STATUS_BOOKING_BOOKED, systemActor, reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	utils.Log.WithFields(logFields).Info("Payment webhook processed")
+	return nil
+}
+
+// SimulatePayment settles a charge of the mock provider and feeds the signed callback
+// through HandleWebhook, exercising the same path a real gateway would.
+func (ps *PaymentService) SimulatePayment(ctx context.Context, req dto.SimulatePaymentRequest) (dto.PaymentResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"paymentID": req.PaymentID,
+		"status":    req.Status,
+	}).Info("Simulating payment")
+
+	mock, ok := ps.provider.(*payment.MockProvider)
+	if !ok {
+		return dto.PaymentResponse{}, constants.ErrPaymentSimulationUnavailable
+	}
+
+	user, err := actorFromContext(ctx, ps.jwtService)
+	if err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	p, err := ps.getPayment(ctx, req.PaymentID)
+	if err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	booking, _, err := ps.bookingRepo.GetBookingByID(ctx, nil, p.BookingID.String())
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", p.BookingID).Error("Booking not found for payment")
+		return dto.PaymentResponse{}, constants.ErrBookingNotFound
+	}
+	if err := authorize(user, booking.UserID, ownerOrAdmin, "simulate payment", logrus.Fields{"paymentID": req.PaymentID}); err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	payload, signature, err := mock.Simulate(p.ProviderRef, p.BookingID.String(), p.Amount, req.Status)
+	if err != nil {
+		utils.Log.WithError(err).WithField("paymentID", p.PaymentID).Error("Failed to build simulated webhook")
+		return dto.PaymentResponse{}, constants.ErrUpdatePayment
+	}
+
+	if err := ps.HandleWebhook(ctx, dto.PaymentWebhookRequest{
+		Provider:  mock.Name(),
+		Payload:   payload,
+		Signature: signature,
+	}); err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	p, err = ps.getPayment(ctx, req.PaymentID)
+	if err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	return toPaymentResponse(p), nil
+}
+
+// RefundPayment returns money for a paid charge through its provider. Amount zero
+// refunds whatever is left; a full refund also moves the booking to refunded.
+func (ps *PaymentService) RefundPayment(ctx context.Context, req dto.RefundPaymentRequest) (dto.PaymentResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"paymentID": req.PaymentID,
+		"amount":    req.Amount,
+	}).Info("Refunding payment")
+
+	admin, err := actorFromContext(ctx, ps.jwtService)
+	if err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	p, err := ps.getPayment(ctx, req.PaymentID)
+	if err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	if p.Status != payment.StatusPaid {
+		utils.Log.WithFields(logrus.Fields{
+			"paymentID": p.PaymentID,
+			"status":    p.Status,
+		}).Warn("Only paid payments can be refunded")
+		return dto.PaymentResponse{}, constants.ErrPaymentNotPaid
+	}
+
+	remaining := roundPrice(p.Amount - p.RefundAmount)
+	amount := req.Amount
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount <= 0 || amount > remaining {
+		utils.Log.WithFields(logrus.Fields{
+			"paymentID": p.PaymentID,
+			"amount":    amount,
+			"remaining": remaining,
+		}).Warn("Invalid refund amount")
+		return dto.PaymentResponse{}, constants.ErrInvalidRefundAmount
+	}
+
+	refund, err := ps.provider.Refund(ctx, p.ProviderRef, amount)
+	if err != nil {
+		utils.Log.WithError(err).WithField("paymentID", p.PaymentID).Error("Payment provider failed to refund")
+		return dto.PaymentResponse{}, constants.ErrRefundPayment
+	}
+
+	refundedAt := refund.RefundedAt.In(helpers.GetAppLocation())
+	p.RefundAmount = roundPrice(p.RefundAmount + refund.Amount)
+	p.RefundedAt = &refundedAt
+	fullyRefunded := p.RefundAmount >= p.Amount
+	if fullyRefunded {
+		p.Status = payment.StatusRefunded
+	}
+
+	var released *model.Booking
+	err = ps.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := ps.paymentRepo.UpdatePayment(ctx, tx, p); err != nil {
+			utils.Log.WithError(err).WithField("paymentID", p.PaymentID).Error("Failed to update refunded payment")
+			return constants.ErrUpdatePayment
+		}
+
+		if !fullyRefunded {
+			return nil
+		}
+
+		booking, _, err := ps.bookingRepo.GetBookingByID(ctx, tx, p.BookingID.String())
+		if err != nil {
+			utils.Log.WithError(err).WithField("bookingID", p.BookingID).Error("Booking not found for payment")
+			return constants.ErrBookingNotFound
+		}
+		if !canTransitionBooking(booking.Status, constants.ENUM_STATUS_BOOKING_REFUNDED) {
+			return nil
+		}
+
+		wasActive := isBookingActive(booking.Status)
+		if err := applyBookingTransition(ctx, tx, ps.bookingRepo, &booking, constants.ENUM_STATUS_BOOKING_REFUNDED, admin, req.Reason); err != nil {
+			return err
+		}
+		if wasActive {
+			released = &booking
+		}
+		return nil
+	})
+	if err != nil {
+		// The provider has already refunded; the record must be fixed by hand.
+		utils.Log.WithError(err).WithFields(logrus.Fields{
+			"paymentID": p.PaymentID,
+			"amount":    refund.Amount,
+		}).Error("Refund issued but not recorded")
+		return dto.PaymentResponse{}, err
+	}
+
+	if released != nil {
+		ps.waitlistService.ReleaseSlot(ctx, *released)
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"paymentID":    p.PaymentID,
+		"refundAmount": p.RefundAmount,
+		"status":       p.Status,
+	}).Info("Payment refunded successfully")
+
+	return toPaymentResponse(p), nil
+}
+
+func (ps *PaymentService) getPayment(ctx context.Context, paymentID string) (model.Payment, error) {
+	if _, err := uuid.Parse(paymentID); err != nil {
+		utils.Log.WithError(err).WithField("paymentID", paymentID).Error("Invalid payment ID format")
+		return model.Payment{}, constants.ErrInvalidUUID
+	}
+
+	p, _, err := ps.paymentRepo.GetPaymentByID(ctx, nil, paymentID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("paymentID", paymentID).Error("Payment not found")
+		return model.Payment{}, constants.ErrPaymentNotFound
+	}
+
+	return p, nil
+}
+
+func toPaymentResponse(p model.Payment) dto.PaymentResponse {
+	return dto.PaymentResponse{
+		PaymentID:    p.PaymentID,
+		BookingID:    p.BookingID,
+		Provider:     p.Provider,
+		ProviderRef:  p.ProviderRef,
+		Amount:       p.Amount,
+		Status:       p.Status,
+		PaymentURL:   p.PaymentURL,
+		ExpiresAt:    p.ExpiresAt,
+		PaidAt:       p.PaidAt,
+		RefundAmount: p.RefundAmount,
+		RefundedAt:   p.RefundedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/helpers"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// ownershipPolicy decides who may act on a resource that belongs to a customer, such as a
+// booking, a booking series or a waitlist entry.
+type ownershipPolicy int
+
+const (
+	// ownerOrAdmin lets the owner in, and admins so they can support any customer.
+	ownerOrAdmin ownershipPolicy = iota
+	// ownerOnly is for actions the customer has to take themselves, like paying or
+	// cancelling. Admins have their own endpoints for these.
+	ownerOnly
+)
+
+func (p ownershipPolicy) allows(by actor, ownerID uuid.UUID) bool {
+	if by.UserID == ownerID {
+		return true
+	}
+	return p == ownerOrAdmin && by.isAdmin()
+}
+
+// authorize enforces policy for a resource owned by ownerID. Refusals are logged with
+// fields identifying the resource and return ErrDeniedAccess.
+func authorize(by actor, ownerID uuid.UUID, policy ownershipPolicy, action string, fields logrus.Fields) error {
+	if policy.allows(by, ownerID) {
+		return nil
+	}
+
+	utils.Log.WithFields(fields).WithFields(logrus.Fields{
+		"userID": by.UserID,
+		"role":   by.Role,
+	}).Warnf("User is not allowed to %s", action)
+	return constants.ErrDeniedAccess
+}
+
+// requireVerifiedEmail refuses users who have not verified their email address when
+// REQUIRE_EMAIL_VERIFICATION is on, so throwaway accounts cannot hold slots.
+func requireVerifiedEmail(ctx context.Context, userRepo repository.IUserRepository, userID uuid.UUID) error {
+	if !helpers.GetEnvBool("REQUIRE_EMAIL_VERIFICATION", false) {
+		return nil
+	}
+
+	user, _, err := userRepo.GetUserByID(ctx, nil, userID.String())
+	if err != nil {
+		utils.Log.WithError(err).WithField("userID", userID).Error("Failed to get user for email verification check")
+		return constants.ErrGetUserByID
+	}
+	if user.EmailVerifiedAt == nil {
+		utils.Log.WithField("userID", userID).Warn("User with unverified email is not allowed to book")
+		return constants.ErrEmailNotVerified
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	IPricingRuleService interface {
+		CreatePricingRule(ctx context.Context, req dto.CreatePricingRuleRequest) (dto.PricingRuleResponse, error)
+		GetPricingRuleByID(ctx context.Context, ruleID string) (dto.PricingRuleResponse, error)
+		GetPricingRulesByFieldID(ctx context.Context, fieldID string) ([]dto.PricingRuleResponse, error)
+		UpdatePricingRule(ctx context.Context, req dto.UpdatePricingRuleRequest) (dto.PricingRuleResponse, error)
+		DeletePricingRule(ctx context.Context, req dto.DeletePricingRuleRequest) (dto.PricingRuleResponse, error)
+	}
+
+	PricingRuleService struct {
+		pricingRuleRepo repository.IPricingRuleRepository
+		fieldRepo       repository.IFieldRepository
+	}
+)
+
+func NewPricingRuleService(pricingRuleRepo repository.IPricingRuleRepository, fieldRepo repository.IFieldRepository) *PricingRuleService {
+	return &PricingRuleService{
+		pricingRuleRepo: pricingRuleRepo,
+		fieldRepo:       fieldRepo,
+	}
+}
+
+func (prs *PricingRuleService) CreatePricingRule(ctx context.Context, req dto.CreatePricingRuleRequest) (dto.PricingRuleResponse, error) {
+	utils.Log.Infof("Creating pricing rule for field ID: %s", req.FieldID)
+
+	fieldUUID, err := uuid.Parse(req.FieldID)
+	if err != nil {
+		utils.Log.Errorf("Invalid field UUID: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrInvalidUUID
+	}
+
+	if _, _, err := prs.fieldRepo.GetFieldByID(ctx, nil, req.FieldID); err != nil {
+		utils.Log.Errorf("Field not found: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrFieldNotFound
+	}
+
+	rule := model.PricingRule{
+		PricingRuleID: uuid.New(),
+		FieldID:       fieldUUID,
+		Name:          req.Name,
+		DayOfWeek:     req.DayOfWeek,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		PricePerHour:  req.PricePerHour,
+		Priority:      req.Priority,
+	}
+
+	if rule.ValidFrom, err = parseOptionalDate(req.ValidFrom); err != nil {
+		return dto.PricingRuleResponse{}, err
+	}
+	if rule.ValidUntil, err = parseOptionalDate(req.ValidUntil); err != nil {
+		return dto.PricingRuleResponse{}, err
+	}
+
+	if err := validatePricingRule(rule); err != nil {
+		return dto.PricingRuleResponse{}, err
+	}
+
+	if err := prs.pricingRuleRepo.CreatePricingRule(ctx, nil, rule); err != nil {
+		utils.Log.Errorf("Failed to create pricing rule: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrCreatePricingRule
+	}
+
+	utils.Log.Infof("Pricing rule created successfully: %s", rule.PricingRuleID)
+
+	return toPricingRuleResponse(rule), nil
+}
+
+func (prs *PricingRuleService) GetPricingRuleByID(ctx context.Context, ruleID string) (dto.PricingRuleResponse, error) {
+	utils.Log.Infof("Fetching pricing rule by ID: %s", ruleID)
+
+	if _, err := uuid.Parse(ruleID); err != nil {
+		utils.Log.Errorf("Invalid pricing rule UUID: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrInvalidUUID
+	}
+
+	rule, _, err := prs.pricingRuleRepo.GetPricingRuleByID(ctx, nil, ruleID)
+	if err != nil {
+		utils.Log.Errorf("Pricing rule not found: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrPricingRuleNotFound
+	}
+
+	return toPricingRuleResponse(rule), nil
+}
+
+func (prs *PricingRuleService) GetPricingRulesByFieldID(ctx context.Context, fieldID string) ([]dto.PricingRuleResponse, error) {
+	utils.Log.Infof("Fetching pricing rules by field ID: %s", fieldID)
+
+	if _, err := uuid.Parse(fieldID); err != nil {
+		utils.Log.Errorf("Invalid field UUID: %v", err)
+		return nil, constants.ErrInvalidUUID
+	}
+
+	rules, err := prs.pricingRuleRepo.GetPricingRulesByFieldID(ctx, nil, fieldID)
+	if err != nil {
+		utils.Log.Errorf("Failed to get pricing rules: %v", err)
+		return nil, constants.ErrGetPricingRule
+	}
+
+	utils.Log.Infof("Found %d pricing rules for field ID: %s", len(rules), fieldID)
+
+	var res []dto.PricingRuleResponse
+	for _, rule := range rules {
+		res = append(res, toPricingRuleResponse(rule))
+	}
+
+	return res, nil
+}
+
+func (prs *PricingRuleService) UpdatePricingRule(ctx context.Context, req dto.UpdatePricingRuleRequest) (dto.PricingRuleResponse, error) {
+	utils.Log.Infof("Updating pricing rule: %s", req.PricingRuleID)
+
+	if _, err := uuid.Parse(req.PricingRuleID); err != nil {
+		utils.Log.Errorf("Invalid pricing rule UUID: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrInvalidUUID
+	}
+
+	rule, _, err := prs.pricingRuleRepo.GetPricingRuleByID(ctx, nil, req.PricingRuleID)
+	if err != nil {
+		utils.Log.Errorf("Pricing rule not found: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrPricingRuleNotFound
+	}
+
+	if req.Name != nil {
+		rule.Name = *req.Name
+	}
+	if req.ClearDayOfWeek && req.DayOfWeek != nil {
+		utils.Log.Warnf("Pricing rule %s update both sets and clears day_of_week", req.PricingRuleID)
+		return dto.PricingRuleResponse{}, constants.ErrInvalidDayOfWeek
+	}
+	if req.ClearDayOfWeek {
+		rule.DayOfWeek = nil
+	}
+	if req.DayOfWeek != nil {
+		rule.DayOfWeek = req.DayOfWeek
+	}
+	if req.StartTime != nil {
+		rule.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		rule.EndTime = *req.EndTime
+	}
+	if req.ValidFrom != nil {
+		if rule.ValidFrom, err = parseOptionalDate(*req.ValidFrom); err != nil {
+			return dto.PricingRuleResponse{}, err
+		}
+	}
+	if req.ValidUntil != nil {
+		if rule.ValidUntil, err = parseOptionalDate(*req.ValidUntil); err != nil {
+			return dto.PricingRuleResponse{}, err
+		}
+	}
+	if req.PricePerHour != nil {
+		rule.PricePerHour = *req.PricePerHour
+	}
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	}
+
+	if err := validatePricingRule(rule); err != nil {
+		return dto.PricingRuleResponse{}, err
+	}
+
+	if err := prs.pricingRuleRepo.UpdatePricingRule(ctx, nil, rule); err != nil {
+		utils.Log.Errorf("Failed to update pricing rule: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrUpdatePricingRule
+	}
+
+	utils.Log.Infof("Pricing rule updated successfully: %s", req.PricingRuleID)
+
+	return toPricingRuleResponse(rule), nil
+}
+
+func (prs *PricingRuleService) DeletePricingRule(ctx context.Context, req dto.DeletePricingRuleRequest) (dto.PricingRuleResponse, error) {
+	utils.Log.Infof("Deleting pricing rule: %s", req.PricingRuleID)
+
+	if _, err := uuid.Parse(req.PricingRuleID); err != nil {
+		utils.Log.Errorf("Invalid pricing rule UUID: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrInvalidUUID
+	}
+
+	rule, _, err := prs.pricingRuleRepo.GetPricingRuleByID(ctx, nil, req.PricingRuleID)
+	if err != nil {
+		utils.Log.Errorf("Pricing rule not found: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrPricingRuleNotFound
+	}
+
+	if err := prs.pricingRuleRepo.DeletePricingRule(ctx, nil, req.PricingRuleID); err != nil {
+		utils.Log.Errorf("Failed to delete pricing rule: %v", err)
+		return dto.PricingRuleResponse{}, constants.ErrDeletePricingRule
+	}
+
+	utils.Log.Infof("Pricing rule deleted successfully: %s", req.PricingRuleID)
+
+	return toPricingRuleResponse(rule), nil
+}
+
+func validatePricingRule(rule model.PricingRule) error {
+	if rule.DayOfWeek != nil && (*rule.DayOfWeek < 0 || *rule.DayOfWeek > 6) {
+		utils.Log.Warn("Invalid day of week for pricing rule")
+		return constants.ErrInvalidDayOfWeek
+	}
+
+	start, err := time.Parse("15:04", rule.StartTime)
+	if err != nil {
+		utils.Log.Warnf("Invalid pricing rule start time: %s", rule.StartTime)
+		return constants.ErrInvalidStartTime
+	}
+	end, err := time.Parse("15:04", rule.EndTime)
+	if err != nil {
+		utils.Log.Warnf("Invalid pricing rule end time: %s", rule.EndTime)
+		return constants.ErrInvalidEndTime
+	}
+	if !end.After(start) {
+		utils.Log.Warn("Pricing rule end time must be after start time")
+		return constants.ErrInvalidTimeRange
+	}
+
+	if rule.ValidFrom != nil && rule.ValidUntil != nil && rule.ValidUntil.Before(*rule.ValidFrom) {
+		utils.Log.Warn("Pricing rule valid until is before valid from")
+		return constants.ErrInvalidDateRange
+	}
+
+	if rule.PricePerHour < 0 {
+		utils.Log.Warn("Invalid pricing rule price: < 0")
+		return constants.ErrInvalidRulePrice
+	}
+
+	return nil
+}
+
+func parseOptionalDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, helpers.GetAppLocation())
+	if err != nil {
+		utils.Log.Warnf("Invalid date value: %s", value)
+		return nil, constants.ErrInvalidDateFormat
+	}
+
+	return &date, nil
+}
+
+func toPricingRuleResponse(rule model.PricingRule) dto.PricingRuleResponse {
+	res := dto.PricingRuleResponse{
+		PricingRuleID: rule.PricingRuleID,
+		FieldID:       rule.FieldID,
+		Name:          rule.Name,
+		DayOfWeek:     rule.DayOfWeek,
+		StartTime:     rule.StartTime,
+		EndTime:       rule.EndTime,
+		PricePerHour:  rule.PricePerHour,
+		Priority:      rule.Priority,
+	}
+
+	if rule.DayOfWeek != nil {
+		res.DayName = helpers.DayIntToName(*rule.DayOfWeek)
+	}
+	if rule.ValidFrom != nil {
+		res.ValidFrom = rule.ValidFrom.Format("2006-01-02")
+	}
+	if rule.ValidUntil != nil {
+		res.ValidUntil = rule.ValidUntil.Format("2006-01-02")
+	}
+
+	return res
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	IPricingService interface {
+		CalculatePrice(ctx context.Context, params PriceParams) (dto.PriceBreakdownResponse, error)
+		GetFieldPricingRules(ctx context.Context, fieldID uuid.UUID) (FieldPricingRules, error)
+		CalculatePriceWithRules(params PriceParams, rules FieldPricingRules) (dto.PriceBreakdownResponse, error)
+	}
+
+	// FieldPricingRules holds every pricing rule of one field so that many slots can be
+	// priced without going back to the database for each of them.
+	FieldPricingRules []model.PricingRule
+
+	// PriceParams describes the slot being priced. Start and end must fall on the same day.
+	// Voucher is optional and must already be validated for the customer and field.
+	PriceParams struct {
+		Field     model.Field
+		StartTime time.Time
+		EndTime   time.Time
+		Voucher   *model.Voucher
+	}
+
+	PricingService struct {
+		pricingRuleRepo repository.IPricingRuleRepository
+	}
+
+	priceSegment struct {
+		start time.Time
+		end   time.Time
+		rule  *model.PricingRule
+	}
+)
+
+func NewPricingService(pricingRuleRepo repository.IPricingRuleRepository) *PricingService {
+	return &PricingService{
+		pricingRuleRepo: pricingRuleRepo,
+	}
+}
+
+// CalculatePrice is the single source of truth for what a booking costs. The
+// resulting items are ordered as: base rates, surcharges, discounts, fees.
+func (ps *PricingService) CalculatePrice(ctx context.Context, params PriceParams) (dto.PriceBreakdownResponse, error) {
+	if !params.EndTime.After(params.StartTime) {
+		utils.Log.Warnf("Cannot price invalid time range %s - %s", params.StartTime, params.EndTime)
+		return dto.PriceBreakdownResponse{}, constants.ErrInvalidTimeRange
+	}
+
+	rules, err := ps.pricingRuleRepo.GetActivePricingRules(ctx, nil, params.Field.FieldID, params.StartTime)
+	if err != nil {
+		utils.Log.Errorf("Failed to get pricing rules for field %s: %v", params.Field.FieldID, err)
+		return dto.PriceBreakdownResponse{}, constants.ErrGetPricingRule
+	}
+
+	return priceWithRules(params, rules)
+}
+
+// GetFieldPricingRules loads all pricing rules of a field in one query, for callers that
+// price many slots of the same field with CalculatePriceWithRules.
+func (ps *PricingService) GetFieldPricingRules(ctx context.Context, fieldID uuid.UUID) (FieldPricingRules, error) {
+	rules, err := ps.pricingRuleRepo.GetPricingRulesByFieldID(ctx, nil, fieldID.String())
+	if err != nil {
+		utils.Log.Errorf("Failed to get pricing rules for field %s: %v", fieldID, err)
+		return nil, constants.ErrGetPricingRule
+	}
+
+	return FieldPricingRules(rules), nil
+}
+
+// CalculatePriceWithRules prices a slot like CalculatePrice, but against rules loaded
+// earlier with GetFieldPricingRules instead of querying them again.
+func (ps *PricingService) CalculatePriceWithRules(params PriceParams, rules FieldPricingRules) (dto.PriceBreakdownResponse, error) {
+	if !params.EndTime.After(params.StartTime) {
+		utils.Log.Warnf("Cannot price invalid time range %s - %s", params.StartTime, params.EndTime)
+		return dto.PriceBreakdownResponse{}, constants.ErrInvalidTimeRange
+	}
+
+	return priceWithRules(params, rules.activeOn(params.StartTime))
+}
+
+// activeOn mirrors the repository's GetActivePricingRules: the rules that apply on the
+// given date, highest priority first.
+func (rules FieldPricingRules) activeOn(date time.Time) []model.PricingRule {
+	day := date.Format("2006-01-02")
+
+	var res []model.PricingRule
+	for _, rule := range rules {
+		if rule.DayOfWeek != nil && *rule.DayOfWeek != int(date.Weekday()) {
+			continue
+		}
+		if rule.ValidFrom != nil && rule.ValidFrom.Format("2006-01-02") > day {
+			continue
+		}
+		if rule.ValidUntil != nil && rule.ValidUntil.Format("2006-01-02") < day {
+			continue
+		}
+		res = append(res, rule)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Priority != res[j].Priority {
+			return res[i].Priority > res[j].Priority
+		}
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+
+	return res
+}
+
+// priceWithRules builds the breakdown for a slot from the rules active on its date,
+// sorted by priority, highest first.
+func priceWithRules(params PriceParams, rules []model.PricingRule) (dto.PriceBreakdownResponse, error) {
+	var items []dto.PriceItemResponse
+
+	// === Base rate, split on pricing rule boundaries ===
+	for _, segment := range splitByPricingRules(params.StartTime, params.EndTime, rules) {
+		hours := segment.end.Sub(segment.start).Hours()
+		label := "Base rate"
+		unitPrice := float64(params.Field.FieldPrice)
+		if segment.rule != nil {
+			label = segment.rule.Name
+			unitPrice = float64(segment.rule.PricePerHour)
+		}
+
+		items = append(items, dto.PriceItemResponse{
+			ItemType:  constants.ENUM_PRICE_ITEM_BASE_RATE,
+			Label:     fmt.Sprintf("%s %s-%s", label, segment.start.Format("15:04"), segment.end.Format("15:04")),
+			Quantity:  hours,
+			UnitPrice: unitPrice,
+			Amount:    roundPrice(unitPrice * hours),
+		})
+	}
+
+	// === Discounts ===
+	if params.Voucher != nil {
+		var subtotal float64
+		for _, item := range items {
+			subtotal += item.Amount
+		}
+
+		if subtotal < params.Voucher.MinSpend {
+			utils.Log.Warnf("Subtotal %.0f below minimum spend %.0f for voucher %s", subtotal, params.Voucher.MinSpend, params.Voucher.Code)
+			return dto.PriceBreakdownResponse{}, constants.ErrVoucherMinSpend
+		}
+
+		if discount := voucherDiscount(*params.Voucher, subtotal); discount > 0 {
+			items = append(items, dto.PriceItemResponse{
+				ItemType:  constants.ENUM_PRICE_ITEM_DISCOUNT,
+				Label:     fmt.Sprintf("Promo %s", params.Voucher.Code),
+				Quantity:  1,
+				UnitPrice: -discount,
+				Amount:    -discount,
+			})
+		}
+	}
+
+	// === Fees ===
+	if fee := helpers.GetEnvInt("BOOKING_SERVICE_FEE", 0); fee > 0 {
+		items = append(items, dto.PriceItemResponse{
+			ItemType:  constants.ENUM_PRICE_ITEM_FEE,
+			Label:     "Service fee",
+			Quantity:  1,
+			UnitPrice: float64(fee),
+			Amount:    float64(fee),
+		})
+	}
+
+	var total, discount float64
+	for _, item := range items {
+		total += item.Amount
+		if item.ItemType == constants.ENUM_PRICE_ITEM_DISCOUNT {
+			discount -= item.Amount
+		}
+	}
+	if total < 0 {
+		total = 0
+	}
+
+	return dto.PriceBreakdownResponse{
+		Items:    items,
+		Discount: discount,
+		Total:    roundPrice(total),
+	}, nil
+}
+
+// voucherDiscount is the amount a voucher takes off subtotal. Percentage discounts are
+// capped by MaxDiscount when it is set, and no discount exceeds the subtotal.
+func voucherDiscount(voucher model.Voucher, subtotal float64) float64 {
+	discount := voucher.DiscountValue
+	if voucher.DiscountType == constants.ENUM_DISCOUNT_PERCENT {
+		discount = subtotal * voucher.DiscountValue / 100
+		if voucher.MaxDiscount > 0 && discount > voucher.MaxDiscount {
+			discount = voucher.MaxDiscount
+		}
+	}
+	if discount > subtotal {
+		discount = subtotal
+	}
+
+	return roundPrice(discount)
+}
+
+// splitByPricingRules cuts [start, end) at every rule boundary that falls inside it and
+// assigns each piece to the highest priority rule covering it. Rules must be sorted by
+// priority, highest first. Adjacent pieces priced by the same rule are merged.
+func splitByPricingRules(start, end time.Time, rules []model.PricingRule) []priceSegment {
+	ruleWindow := func(rule model.PricingRule) (time.Time, time.Time) {
+		ruleStart, _ := time.Parse("15:04", rule.StartTime)
+		ruleEnd, _ := time.Parse("15:04", rule.EndTime)
+		return time.Date(start.Year(), start.Month(), start.Day(), ruleStart.Hour(), ruleStart.Minute(), 0, 0, start.Location()),
+			time.Date(start.Year(), start.Month(), start.Day(), ruleEnd.Hour(), ruleEnd.Minute(), 0, 0, start.Location())
+	}
+
+	boundaries := []time.Time{start, end}
+	for _, rule := range rules {
+		ruleStart, ruleEnd := ruleWindow(rule)
+		for _, t := range []time.Time{ruleStart, ruleEnd} {
+			if t.After(start) && t.Before(end) {
+				boundaries = append(boundaries, t)
+			}
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	var segments []priceSegment
+	for i := 0; i < len(boundaries)-1; i++ {
+		segStart, segEnd := boundaries[i], boundaries[i+1]
+		if !segEnd.After(segStart) {
+			continue
+		}
+
+		var matched *model.PricingRule
+		for j := range rules {
+			ruleStart, ruleEnd := ruleWindow(rules[j])
+			if !segStart.Before(ruleStart) && !segEnd.After(ruleEnd) {
+				matched = &rules[j]
+				break
+			}
+		}
+
+		if n := len(segments); n > 0 && segments[n-1].rule == matched {
+			segments[n-1].end = segEnd
+			continue
+		}
+		segments = append(segments, priceSegment{start: segStart, end: segEnd, rule: matched})
+	}
+
+	return segments
+}
+
+func roundPrice(amount float64) float64 {
+	return math.Round(amount)
+}
+
+func toBookingPriceItems(bookingID uuid.UUID, items []dto.PriceItemResponse) []model.BookingPriceItem {
+	var res []model.BookingPriceItem
+	for i, item := range items {
+		res = append(res, model.BookingPriceItem{
+			PriceItemID: uuid.New(),
+			BookingID:   bookingID,
+			ItemType:    item.ItemType,
+			Label:       item.Label,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+			SortOrder:   i,
+		})
+	}
+
+	return res
+}
+
+func toPriceItemResponses(items []model.BookingPriceItem) []dto.PriceItemResponse {
+	var res []dto.PriceItemResponse
+	for _, item := range items {
+		res = append(res, dto.PriceItemResponse{
+			ItemType:  item.ItemType,
+			Label:     item.Label,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Amount:    item.Amount,
+		})
+	}
+
+	return res
+}
@@ -8,6 +8,7 @@ import (
 	"fieldreserve/model"
 	"fieldreserve/repository"
 	"fieldreserve/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,18 +23,30 @@ type (
 
 		GetSchedulesByFieldID(ctx context.Context, fieldID string) ([]dto.ScheduleResponse, error)
 		GetScheduleByFieldIDAndDay(ctx context.Context, fieldID string, day int) (dto.ScheduleResponse, error)
+		GetFieldAvailability(ctx context.Context, req dto.FieldAvailabilityRequest) ([]dto.FieldAvailabilityResponse, error)
 	}
 
 	ScheduleService struct {
-		scheduleRepo repository.IScheduleRepository
-		fieldRepo    repository.IFieldRepository
+		scheduleRepo   repository.IScheduleRepository
+		fieldRepo      repository.IFieldRepository
+		bookingRepo    repository.IBookingRepository
+		pricingService IPricingService
 	}
 )
 
-func NewScheduleService(scheduleRepo repository.IScheduleRepository, fieldRepo repository.IFieldRepository) *ScheduleService {
+const (
+	defaultAvailabilitySlotMinutes = 60
+	minAvailabilitySlotMinutes     = 15
+	maxAvailabilitySlotMinutes     = 240
+	maxAvailabilityRangeDays       = 31
+)
+
+func NewScheduleService(scheduleRepo repository.IScheduleRepository, fieldRepo repository.IFieldRepository, bookingRepo repository.IBookingRepository, pricingService IPricingService) *ScheduleService {
 	return &ScheduleService{
-		scheduleRepo: scheduleRepo,
-		fieldRepo:    fieldRepo,
+		scheduleRepo:   scheduleRepo,
+		fieldRepo:      fieldRepo,
+		bookingRepo:    bookingRepo,
+		pricingService: pricingService,
 	}
 }
 
@@ -129,12 +142,13 @@ func (ss *ScheduleService) GetScheduleByID(ctx context.Context, scheduleID strin
 	field := schedule.Field
 	category := field.Category
 
-	fieldDTO := dto.FieldFullResponse{
+	fieldDTO := dto.FieldCompactResponse{
 		FieldID:      field.FieldID,
 		FieldName:    field.FieldName,
 		FieldAddress: field.FieldAddress,
 		FieldPrice:   field.FieldPrice,
 		FieldImage:   field.FieldImage,
+		ImageURLs:    fieldImageURLs(field.FieldImage),
 		Category: dto.CategoryCompactResponse{
 			CategoryID:  category.CategoryID,
 			Name:        category.Name,
@@ -148,7 +162,7 @@ func (ss *ScheduleService) GetScheduleByID(ctx context.Context, scheduleID strin
 		DayName:    helpers.DayIntToName(schedule.DayOfWeek),
 		OpenTime:   schedule.OpenTime.In(loc).Format("15:04"),
 		CloseTime:  schedule.CloseTime.In(loc).Format("15:04"),
-		Field:      dto.FieldCompactResponse(fieldDTO),
+		Field:      fieldDTO,
 	}
 
 	return res, nil
@@ -314,3 +328,156 @@ func (ss *ScheduleService) GetScheduleByFieldIDAndDay(ctx context.Context, field
 
 	return res, nil
 }
+
+func (ss *ScheduleService) GetFieldAvailability(ctx context.Context, req dto.FieldAvailabilityRequest) ([]dto.FieldAvailabilityResponse, error) {
+	utils.Log.Infof("Fetching availability for field ID: %s", req.FieldID)
+
+	loc := helpers.GetAppLocation()
+
+	fieldID, err := uuid.Parse(req.FieldID)
+	if err != nil {
+		utils.Log.Errorf("Invalid field UUID: %v", err)
+		return nil, constants.ErrInvalidUUID
+	}
+
+	// === [1] Tentukan rentang tanggal ===
+	startStr, endStr := req.StartDate, req.EndDate
+	if req.Date != "" {
+		startStr, endStr = req.Date, req.Date
+	}
+	if startStr == "" {
+		startStr = time.Now().In(loc).Format("2006-01-02")
+	}
+	if endStr == "" {
+		endStr = startStr
+	}
+
+	startDate, err := time.ParseInLocation("2006-01-02", startStr, loc)
+	if err != nil {
+		utils.Log.Errorf("Invalid start date %s: %v", startStr, err)
+		return nil, constants.ErrInvalidBookingDate
+	}
+	endDate, err := time.ParseInLocation("2006-01-02", endStr, loc)
+	if err != nil {
+		utils.Log.Errorf("Invalid end date %s: %v", endStr, err)
+		return nil, constants.ErrInvalidBookingDate
+	}
+	if endDate.Before(startDate) {
+		utils.Log.Warnf("End date %s is before start date %s", endStr, startStr)
+		return nil, constants.ErrInvalidDateRange
+	}
+	if int(endDate.Sub(startDate).Hours()/24)+1 > maxAvailabilityRangeDays {
+		utils.Log.Warnf("Availability range %s - %s is too long", startStr, endStr)
+		return nil, constants.ErrDateRangeTooLong
+	}
+
+	// === [2] Tentukan granularitas slot ===
+	slotMinutes := req.SlotMinutes
+	if slotMinutes == 0 {
+		slotMinutes = helpers.GetEnvInt("AVAILABILITY_SLOT_MINUTES", defaultAvailabilitySlotMinutes)
+	}
+	if slotMinutes < minAvailabilitySlotMinutes || slotMinutes > maxAvailabilitySlotMinutes {
+		utils.Log.Warnf("Invalid slot duration: %d minutes", slotMinutes)
+		return nil, constants.ErrInvalidSlotDuration
+	}
+	slotDuration := time.Duration(slotMinutes) * time.Minute
+
+	// === [3] Ambil data field, jadwal, dan booking aktif ===
+	field, _, err := ss.fieldRepo.GetFieldByID(ctx, nil, req.FieldID)
+	if err != nil {
+		utils.Log.Errorf("Field not found: %v", err)
+		return nil, constants.ErrFieldNotFound
+	}
+
+	schedules, err := ss.scheduleRepo.GetSchedulesByFieldID(ctx, nil, req.FieldID)
+	if err != nil {
+		utils.Log.Errorf("Failed to get schedules by field ID: %v", err)
+		return nil, constants.ErrGetAllSchedule
+	}
+
+	schedulesByDay := make(map[int]model.Schedule, len(schedules))
+	for _, s := range schedules {
+		schedulesByDay[s.DayOfWeek] = s
+	}
+
+	bookings, err := ss.bookingRepo.GetActiveBookingsByFieldAndDateRange(ctx, nil, fieldID, startDate, endDate)
+	if err != nil {
+		utils.Log.Errorf("Failed to get bookings for availability: %v", err)
+		return nil, constants.ErrGetAvailability
+	}
+
+	rules, err := ss.pricingService.GetFieldPricingRules(ctx, field.FieldID)
+	if err != nil {
+		utils.Log.Errorf("Failed to get pricing rules for availability: %v", err)
+		return nil, constants.ErrCalculatePrice
+	}
+
+	// === [4] Susun slot yang masih kosong per tanggal ===
+	earliestStart := time.Now().In(loc).Add(bookingMinLeadTime)
+
+	var res []dto.FieldAvailabilityResponse
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		dayOfWeek := int(date.Weekday())
+		day := dto.FieldAvailabilityResponse{
+			Date:      date.Format("2006-01-02"),
+			DayOfWeek: dayOfWeek,
+			DayName:   helpers.DayIntToName(dayOfWeek),
+			Slots:     []dto.AvailabilitySlotResponse{},
+		}
+
+		schedule, ok := schedulesByDay[dayOfWeek]
+		if !ok {
+			res = append(res, day)
+			continue
+		}
+
+		openTime := schedule.OpenTime.In(loc)
+		closeTime := schedule.CloseTime.In(loc)
+		dayOpen := time.Date(date.Year(), date.Month(), date.Day(), openTime.Hour(), openTime.Minute(), 0, 0, loc)
+		dayClose := time.Date(date.Year(), date.Month(), date.Day(), closeTime.Hour(), closeTime.Minute(), 0, 0, loc)
+
+		day.IsOpen = true
+		day.OpenTime = dayOpen.Format("15:04")
+		day.CloseTime = dayClose.Format("15:04")
+
+		for slotStart := dayOpen; !slotStart.Add(slotDuration).After(dayClose); slotStart = slotStart.Add(slotDuration) {
+			slotEnd := slotStart.Add(slotDuration)
+
+			if slotStart.Before(earliestStart) || isSlotBooked(bookings, slotStart, slotEnd) {
+				continue
+			}
+
+			price, err := ss.pricingService.CalculatePriceWithRules(PriceParams{
+				Field:     field,
+				StartTime: slotStart,
+				EndTime:   slotEnd,
+			}, rules)
+			if err != nil {
+				utils.Log.Errorf("Failed to price slot %s - %s: %v", slotStart, slotEnd, err)
+				return nil, constants.ErrCalculatePrice
+			}
+
+			day.Slots = append(day.Slots, dto.AvailabilitySlotResponse{
+				StartTime: slotStart.Format("15:04"),
+				EndTime:   slotEnd.Format("15:04"),
+				Price:     price.Total,
+			})
+		}
+
+		res = append(res, day)
+	}
+
+	utils.Log.Infof("Availability computed for field ID %s from %s to %s", req.FieldID, startStr, endStr)
+
+	return res, nil
+}
+
+func isSlotBooked(bookings []model.Booking, slotStart, slotEnd time.Time) bool {
+	for _, b := range bookings {
+		if slotStart.Before(b.EndTime) && slotEnd.After(b.StartTime) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/mailer"
+	"fieldreserve/model"
+	"fieldreserve/utils"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func passwordResetTTL() time.Duration {
+	return time.Duration(helpers.GetEnvInt("PASSWORD_RESET_TOKEN_TTL_MINUTES", 30)) * time.Minute
+}
+
+// userTokenLink appends token to the page configured in envKey, e.g. the frontend's reset
+// password form.
+func userTokenLink(envKey, fallback, token string) string {
+	base := os.Getenv(envKey)
+	if base == "" {
+		base = fallback
+	}
+
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "token=" + url.QueryEscape(token)
+}
+
+// ForgotPassword mails a reset link when the address belongs to an account. It succeeds
+// either way so the endpoint does not reveal which addresses are registered; failures
+// after the account is found are only logged for the same reason.
+func (us *UserService) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	if !helpers.IsValidEmail(req.Email) {
+		return constants.ErrInvalidEmail
+	}
+
+	user, found, err := us.userRepo.GetUserByEmail(ctx, nil, req.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Log.WithError(err).WithField("email", req.Email).Error("Failed to get user for password reset")
+		return constants.ErrForgotPassword
+	}
+	if !found {
+		utils.Log.WithField("email", req.Email).Warn("Password reset requested for unknown email")
+		return nil
+	}
+
+	token, tokenHash, err := helpers.GenerateUserToken()
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to generate password reset token")
+		return nil
+	}
+
+	ttl := passwordResetTTL()
+	err = us.tokenRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := us.tokenRepo.InvalidateUserTokens(ctx, tx, user.UserID, constants.ENUM_USER_TOKEN_PASSWORD_RESET); err != nil {
+			return err
+		}
+
+		return us.tokenRepo.CreateUserToken(ctx, tx, model.UserToken{
+			TokenID:   uuid.New(),
+			UserID:    user.UserID,
+			Purpose:   constants.ENUM_USER_TOKEN_PASSWORD_RESET,
+			TokenHash: tokenHash,
+			ExpiresAt: time.Now().Add(ttl),
+		})
+	})
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to save password reset token")
+		return nil
+	}
+
+	link := userTokenLink("PASSWORD_RESET_URL", "http://localhost:3000/reset-password", token)
+	body := fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link works once and expires in %d minutes. If you did not ask for this, you can ignore this email.",
+		user.Name, link, int(ttl.Minutes()))
+	if err := us.mailer.Send(ctx, mailer.Message{To: user.Email, Subject: "Reset your password", Body: body}); err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to send password reset email")
+		return nil
+	}
+
+	utils.Log.WithField("user_id", user.UserID).Info("Password reset email sent")
+
+	return nil
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword and signs the user
+// out everywhere, since whoever knew the old password may still hold a session.
+func (us *UserService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	if len(req.Password) < 8 {
+		return constants.ErrInvalidPassword
+	}
+
+	stored, found, err := us.tokenRepo.GetUserTokenByHash(ctx, nil, constants.ENUM_USER_TOKEN_PASSWORD_RESET, helpers.HashUserToken(req.Token))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Log.WithError(err).Error("Failed to get password reset token")
+		return constants.ErrResetPassword
+	}
+	if !found {
+		return constants.ErrInvalidResetToken
+	}
+
+	user, _, err := us.userRepo.GetUserByID(ctx, nil, stored.UserID.String())
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", stored.UserID).Warn("Password reset for missing user")
+		return constants.ErrInvalidResetToken
+	}
+
+	hashP, err := helpers.HashPassword(req.Password)
+	if err != nil {
+		return constants.ErrHashPassword
+	}
+	user.Password = hashP
+
+	err = us.tokenRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		consumed, err := us.tokenRepo.ConsumeUserToken(ctx, tx, stored.TokenID)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return constants.ErrInvalidResetToken
+		}
+
+		return us.userRepo.UpdateUser(ctx, tx, user)
+	})
+	if errors.Is(err, constants.ErrInvalidResetToken) {
+		return err
+	}
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to reset password")
+		return constants.ErrResetPassword
+	}
+
+	revoked, err := us.endSessions(ctx, user.UserID, uuid.Nil, revokeReasonPasswordReset)
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to end sessions after password reset")
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"user_id":          user.UserID,
+		"sessions_revoked": revoked,
+	}).Info("Password reset")
+
+	return nil
+}
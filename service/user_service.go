@@ -5,6 +5,7 @@ import (
 	"fieldreserve/constants"
 	"fieldreserve/dto"
 	"fieldreserve/helpers"
+	"fieldreserve/mailer"
 	"fieldreserve/model"
 	"fieldreserve/repository"
 	"fieldreserve/utils"
@@ -17,22 +18,36 @@ type (
 	IUserService interface {
 		CreateUser(ctx context.Context, req dto.CreateUserRequest) (dto.UserResponse, error)
 		GetUserByEmail(ctx context.Context, req dto.LoginUserRequest) (dto.LoginResponse, error)
+		RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.LoginResponse, error)
+		Logout(ctx context.Context) error
+		LogoutAll(ctx context.Context) (dto.RevokeSessionsResponse, error)
+		GetSessions(ctx context.Context) ([]dto.SessionResponse, error)
+		RevokeUserSessions(ctx context.Context, userID string) (dto.RevokeSessionsResponse, error)
+		ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error
+		ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+		VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error
+		ResendEmailVerification(ctx context.Context) error
 		GetuserByID(ctx context.Context, userID string) (dto.UserResponse, error)
 		GetAllUserWithPagination(ctx context.Context, req dto.UserPaginationRequest) (dto.UserPaginationResponse, error)
 		UpdateUser(ctx context.Context, req dto.UpdateUserRequest) (dto.UserResponse, error)
+		UpdateUserRole(ctx context.Context, req dto.UpdateUserRoleRequest) (dto.UserResponse, error)
 		DeleteUser(ctx context.Context, req dto.DeleteUserRequest) (dto.UserResponse, error)
 	}
 
 	UserService struct {
 		userRepo   repository.IUserRepository
+		tokenRepo  repository.ITokenRepository
 		jwtService InterfaceJWTService
+		mailer     mailer.Mailer
 	}
 )
 
-func NewUserService(userRepo repository.IUserRepository, jwtService InterfaceJWTService) *UserService {
+func NewUserService(userRepo repository.IUserRepository, tokenRepo repository.ITokenRepository, jwtService InterfaceJWTService, mail mailer.Mailer) *UserService {
 	return &UserService{
 		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
 		jwtService: jwtService,
+		mailer:     mail,
 	}
 }
 
@@ -73,6 +88,12 @@ func (us *UserService) CreateUser(ctx context.Context, req dto.CreateUserRequest
 		"email":   user.Email,
 	}).Info("User created successfully")
 
+	// Registration still succeeds when the email cannot be sent; the user can ask for
+	// another one.
+	if err := us.sendEmailVerification(ctx, user); err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Warn("Failed to send verification email after registration")
+	}
+
 	res := dto.UserResponse{
 		ID:    user.UserID,
 		Name:  user.Name,
@@ -103,7 +124,7 @@ func (us *UserService) GetUserByEmail(ctx context.Context, req dto.LoginUserRequ
 		return dto.LoginResponse{}, constants.ErrPasswordNotMatch
 	}
 
-	accessToken, refreshToken, err := us.jwtService.GenerateToken(user.UserID.String(), user.Role)
+	res, err := us.startSession(ctx, user, req.UserAgent, req.IPAddress)
 	if err != nil {
 		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to generate token")
 		return dto.LoginResponse{}, err
@@ -114,10 +135,7 @@ func (us *UserService) GetUserByEmail(ctx context.Context, req dto.LoginUserRequ
 		"email":   user.Email,
 	}).Info("User login successful")
 
-	return dto.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}, nil
+	return res, nil
 }
 
 func (us *UserService) GetuserByID(ctx context.Context, userID string) (dto.UserResponse, error) {
@@ -137,6 +155,8 @@ func (us *UserService) GetuserByID(ctx context.Context, userID string) (dto.User
 		Email:   user.Email,
 		Address: user.Address,
 		NoTelp:  user.NoTelp,
+
+		EmailVerifiedAt: user.EmailVerifiedAt,
 	}
 
 	return res, nil
@@ -157,6 +177,8 @@ func (us *UserService) GetAllUserWithPagination(ctx context.Context, req dto.Use
 			Email:   user.Email,
 			Address: user.Address,
 			NoTelp:  user.NoTelp,
+
+			EmailVerifiedAt: user.EmailVerifiedAt,
 		}
 		datas = append(datas, data)
 	}
@@ -179,6 +201,7 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 		return dto.UserResponse{}, constants.ErrGetUserByID
 	}
 
+	emailChanged := false
 	if req.Email != "" && req.Email != user.Email {
 		if !helpers.IsValidEmail(req.Email) {
 			return dto.UserResponse{}, constants.ErrInvalidEmail
@@ -190,6 +213,7 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 		}
 
 		user.Email = req.Email
+		emailChanged = true
 	}
 
 	if req.Name != "" {
@@ -229,11 +253,33 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 		return dto.UserResponse{}, constants.ErrUpdateUser
 	}
 
+	if emailChanged {
+		// A new address has to be verified again before the user can book.
+		if err := us.userRepo.SetEmailVerifiedAt(ctx, nil, user.UserID.String(), nil); err != nil {
+			utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to reset email verification")
+			return dto.UserResponse{}, constants.ErrUpdateUser
+		}
+		if err := us.sendEmailVerification(ctx, user); err != nil {
+			utils.Log.WithError(err).WithField("user_id", user.UserID).Warn("Failed to send verification email after email change")
+		}
+	}
+
 	utils.Log.WithFields(logrus.Fields{
 		"user_id": user.UserID,
 		"email":   user.Email,
 	}).Info("User updated successfully")
 
+	if req.Password != "" {
+		// A new password signs out every other device; the session that changed it stays.
+		keep := uuid.Nil
+		if session, err := us.currentSession(ctx); err == nil && session.UserID == user.UserID.String() {
+			keep = session.SessionID
+		}
+		if _, err := us.endSessions(ctx, user.UserID, keep, revokeReasonPasswordChanged); err != nil {
+			utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to end sessions after password change")
+		}
+	}
+
 	res := dto.UserResponse{
 		ID:      user.UserID,
 		Name:    user.Name,
@@ -245,6 +291,59 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 	return res, nil
 }
 
+// UpdateUserRole lets an admin promote a user to staff or admin, or demote them. The new
+// role applies from the user's next token refresh, when a token carrying it is issued.
+func (us *UserService) UpdateUserRole(ctx context.Context, req dto.UpdateUserRoleRequest) (dto.UserResponse, error) {
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		return dto.UserResponse{}, constants.ErrInvalidUUID
+	}
+
+	switch req.Role {
+	case constants.ENUM_ROLE_USER, constants.ENUM_ROLE_STAFF, constants.ENUM_ROLE_ADMIN:
+	default:
+		return dto.UserResponse{}, constants.ErrInvalidRole
+	}
+
+	by, err := actorFromContext(ctx, us.jwtService)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+	if by.UserID.String() == req.UserID {
+		// Admins cannot demote themselves and lock the last admin out by accident.
+		utils.Log.WithField("user_id", req.UserID).Warn("Admin attempted to change own role")
+		return dto.UserResponse{}, constants.ErrDeniedAccess
+	}
+
+	user, _, err := us.userRepo.GetUserByID(ctx, nil, req.UserID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", req.UserID).Error("Failed to get user for role update")
+		return dto.UserResponse{}, constants.ErrGetUserByID
+	}
+
+	previousRole := user.Role
+	user.Role = req.Role
+	if err := us.userRepo.UpdateUser(ctx, nil, user); err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to update user role")
+		return dto.UserResponse{}, constants.ErrUpdateUser
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"user_id": user.UserID,
+		"from":    previousRole,
+		"to":      user.Role,
+		"by":      by.UserID,
+	}).Info("User role updated")
+
+	return dto.UserResponse{
+		ID:      user.UserID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Address: user.Address,
+		NoTelp:  user.NoTelp,
+		Role:    user.Role,
+	}, nil
+}
+
 func (us *UserService) DeleteUser(ctx context.Context, req dto.DeleteUserRequest) (dto.UserResponse, error) {
 	deletedUser, _, err := us.userRepo.GetUserByID(ctx, nil, req.UserID)
 	if err != nil {
@@ -263,6 +362,10 @@ func (us *UserService) DeleteUser(ctx context.Context, req dto.DeleteUserRequest
 		"email":   deletedUser.Email,
 	}).Info("User deleted successfully")
 
+	if _, err := us.endSessions(ctx, deletedUser.UserID, uuid.Nil, revokeReasonUserDeleted); err != nil {
+		utils.Log.WithError(err).WithField("user_id", deletedUser.UserID).Error("Failed to end sessions of deleted user")
+	}
+
 	res := dto.UserResponse{
 		ID:      deletedUser.UserID,
 		Name:    deletedUser.Name,
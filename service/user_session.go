@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/model"
+	"fieldreserve/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	revokeReasonRefreshReuse    = "refresh token reused"
+	revokeReasonLogout          = "logout"
+	revokeReasonLogoutAll       = "logout all"
+	revokeReasonAdmin           = "revoked by admin"
+	revokeReasonPasswordChanged = "password changed"
+	revokeReasonPasswordReset   = "password reset"
+	revokeReasonUserDeleted     = "user deleted"
+)
+
+// startSession issues the first token pair of a login and opens a new token family for it.
+func (us *UserService) startSession(ctx context.Context, user model.User, userAgent, ipAddress string) (dto.LoginResponse, error) {
+	familyID := uuid.New()
+	accessToken, refreshToken, err := us.jwtService.GenerateToken(user.UserID.String(), user.Role, familyID.String())
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	claims, err := us.jwtService.ParseToken(refreshToken, constants.TOKEN_TYPE_REFRESH)
+	if err != nil {
+		return dto.LoginResponse{}, constants.ErrGenerateRefreshToken
+	}
+
+	err = us.tokenRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		family := model.TokenFamily{
+			FamilyID:   familyID,
+			UserID:     user.UserID,
+			UserAgent:  userAgent,
+			IPAddress:  ipAddress,
+			LastSeenAt: time.Now(),
+			ExpiresAt:  claims.ExpiresAt,
+		}
+		if err := us.tokenRepo.CreateTokenFamily(ctx, tx, family); err != nil {
+			return err
+		}
+
+		return us.tokenRepo.CreateRefreshToken(ctx, tx, model.RefreshToken{
+			TokenID:   claims.TokenID,
+			FamilyID:  family.FamilyID,
+			UserID:    user.UserID,
+			ExpiresAt: claims.ExpiresAt,
+		})
+	})
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to persist refresh token")
+		return dto.LoginResponse{}, constants.ErrGenerateRefreshToken
+	}
+
+	return dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new pair. Each refresh token can be used
+// once; presenting one that was already rotated means it leaked, so the whole family is
+// revoked and the user has to log in again.
+func (us *UserService) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.LoginResponse, error) {
+	claims, err := us.jwtService.ParseToken(req.RefreshToken, constants.TOKEN_TYPE_REFRESH)
+	if err != nil {
+		return dto.LoginResponse{}, constants.ErrInvalidRefreshToken
+	}
+
+	stored, found, err := us.tokenRepo.GetRefreshTokenByID(ctx, nil, claims.TokenID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Log.WithError(err).WithField("token_id", claims.TokenID).Error("Failed to get refresh token")
+		return dto.LoginResponse{}, constants.ErrRefreshToken
+	}
+	if !found || stored.FamilyID != claims.SessionID {
+		return dto.LoginResponse{}, constants.ErrInvalidRefreshToken
+	}
+
+	family, _, err := us.tokenRepo.GetTokenFamilyByID(ctx, nil, stored.FamilyID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("family_id", stored.FamilyID).Error("Failed to get token family")
+		return dto.LoginResponse{}, constants.ErrRefreshToken
+	}
+	if family.RevokedAt != nil {
+		return dto.LoginResponse{}, constants.ErrInvalidRefreshToken
+	}
+
+	if stored.UsedAt != nil {
+		us.revokeReusedFamily(ctx, stored)
+		return dto.LoginResponse{}, constants.ErrRefreshTokenReused
+	}
+
+	user, found, err := us.userRepo.GetUserByID(ctx, nil, stored.UserID.String())
+	if err != nil || !found {
+		utils.Log.WithError(err).WithField("user_id", stored.UserID).Warn("Refresh failed: user not found")
+		return dto.LoginResponse{}, constants.ErrInvalidRefreshToken
+	}
+
+	// The role comes from the user row rather than the old token so role changes apply
+	// on the next refresh.
+	accessToken, refreshToken, err := us.jwtService.GenerateToken(user.UserID.String(), user.Role, stored.FamilyID.String())
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to generate token")
+		return dto.LoginResponse{}, err
+	}
+
+	newClaims, err := us.jwtService.ParseToken(refreshToken, constants.TOKEN_TYPE_REFRESH)
+	if err != nil {
+		return dto.LoginResponse{}, constants.ErrGenerateRefreshToken
+	}
+
+	err = us.tokenRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		rotated, err := us.tokenRepo.MarkRefreshTokenUsed(ctx, tx, stored.TokenID, newClaims.TokenID)
+		if err != nil {
+			return err
+		}
+		if !rotated {
+			return constants.ErrRefreshTokenReused
+		}
+
+		if err := us.tokenRepo.CreateRefreshToken(ctx, tx, model.RefreshToken{
+			TokenID:   newClaims.TokenID,
+			FamilyID:  stored.FamilyID,
+			UserID:    user.UserID,
+			ExpiresAt: newClaims.ExpiresAt,
+		}); err != nil {
+			return err
+		}
+
+		return us.tokenRepo.TouchTokenFamily(ctx, tx, stored.FamilyID, req.UserAgent, req.IPAddress, newClaims.ExpiresAt)
+	})
+	if errors.Is(err, constants.ErrRefreshTokenReused) {
+		us.revokeReusedFamily(ctx, stored)
+		return dto.LoginResponse{}, constants.ErrRefreshTokenReused
+	}
+	if err != nil {
+		utils.Log.WithError(err).WithField("token_id", stored.TokenID).Error("Failed to rotate refresh token")
+		return dto.LoginResponse{}, constants.ErrRefreshToken
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"user_id":   user.UserID,
+		"family_id": stored.FamilyID,
+	}).Info("Refresh token rotated")
+
+	return dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (us *UserService) revokeReusedFamily(ctx context.Context, stored model.RefreshToken) {
+	fields := logrus.Fields{
+		"user_id":   stored.UserID,
+		"family_id": stored.FamilyID,
+		"token_id":  stored.TokenID,
+	}
+
+	if err := us.tokenRepo.RevokeTokenFamily(ctx, nil, stored.FamilyID, revokeReasonRefreshReuse); err != nil {
+		utils.Log.WithError(err).WithFields(fields).Error("Failed to revoke token family after refresh token reuse")
+		return
+	}
+
+	// Whoever holds the leaked token may also hold access tokens issued from it.
+	if err := us.jwtService.RevokeSession(ctx, stored.FamilyID); err != nil {
+		utils.Log.WithError(err).WithFields(fields).Error("Failed to revoke session after refresh token reuse")
+		return
+	}
+
+	utils.Log.WithFields(fields).Warn("Refresh token reused, token family revoked")
+}
+
+// currentSession returns the claims of the access token that authenticated the request.
+func (us *UserService) currentSession(ctx context.Context) (TokenClaims, error) {
+	tokenStr, ok := ctx.Value("token").(string)
+	if !ok || tokenStr == "" {
+		return TokenClaims{}, constants.ErrUnauthorized
+	}
+
+	claims, err := us.jwtService.ParseToken(tokenStr, constants.TOKEN_TYPE_ACCESS)
+	if err != nil {
+		return TokenClaims{}, constants.ErrUnauthorized
+	}
+
+	return claims, nil
+}
+
+// Logout ends the session behind the request: its refresh token stops working and its
+// access tokens are rejected from now on.
+func (us *UserService) Logout(ctx context.Context) error {
+	session, err := us.currentSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	fields := logrus.Fields{
+		"user_id":    session.UserID,
+		"session_id": session.SessionID,
+	}
+
+	if err := us.tokenRepo.RevokeTokenFamily(ctx, nil, session.SessionID, revokeReasonLogout); err != nil {
+		utils.Log.WithError(err).WithFields(fields).Error("Failed to revoke token family on logout")
+		return constants.ErrLogout
+	}
+
+	if err := us.jwtService.RevokeSession(ctx, session.SessionID); err != nil {
+		utils.Log.WithError(err).WithFields(fields).Error("Failed to revoke session on logout")
+		return constants.ErrLogout
+	}
+
+	utils.Log.WithFields(fields).Info("User logged out")
+
+	return nil
+}
+
+// LogoutAll ends every session of the requesting user, including the current one.
+func (us *UserService) LogoutAll(ctx context.Context) (dto.RevokeSessionsResponse, error) {
+	session, err := us.currentSession(ctx)
+	if err != nil {
+		return dto.RevokeSessionsResponse{}, err
+	}
+
+	userID, err := uuid.Parse(session.UserID)
+	if err != nil {
+		return dto.RevokeSessionsResponse{}, constants.ErrInvalidUUID
+	}
+
+	revoked, err := us.endSessions(ctx, userID, uuid.Nil, revokeReasonLogoutAll)
+	if err != nil {
+		return dto.RevokeSessionsResponse{}, err
+	}
+
+	return dto.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+// RevokeUserSessions lets an admin sign a user out everywhere, e.g. when an account is
+// compromised or being suspended.
+func (us *UserService) RevokeUserSessions(ctx context.Context, userID string) (dto.RevokeSessionsResponse, error) {
+	targetID, err := uuid.Parse(userID)
+	if err != nil {
+		return dto.RevokeSessionsResponse{}, constants.ErrInvalidUUID
+	}
+
+	by, err := actorFromContext(ctx, us.jwtService)
+	if err != nil {
+		return dto.RevokeSessionsResponse{}, err
+	}
+
+	if _, _, err := us.userRepo.GetUserByID(ctx, nil, userID); err != nil {
+		utils.Log.WithError(err).WithField("user_id", userID).Error("Failed to get user for session revocation")
+		return dto.RevokeSessionsResponse{}, constants.ErrGetUserByID
+	}
+
+	revoked, err := us.endSessions(ctx, targetID, uuid.Nil, revokeReasonAdmin)
+	if err != nil {
+		return dto.RevokeSessionsResponse{}, err
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"user_id": targetID,
+		"by":      by.UserID,
+		"revoked": revoked,
+	}).Info("User sessions revoked by admin")
+
+	return dto.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+// GetSessions lists the requesting user's active sessions. Last-seen times move on login
+// and on every refresh, which an active client does at least once per access token.
+func (us *UserService) GetSessions(ctx context.Context) ([]dto.SessionResponse, error) {
+	session, err := us.currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(session.UserID)
+	if err != nil {
+		return nil, constants.ErrInvalidUUID
+	}
+
+	families, err := us.tokenRepo.GetActiveTokenFamilies(ctx, nil, userID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", userID).Error("Failed to get sessions")
+		return nil, constants.ErrGetSessions
+	}
+
+	res := make([]dto.SessionResponse, 0, len(families))
+	for _, family := range families {
+		res = append(res, dto.SessionResponse{
+			SessionID:  family.FamilyID,
+			Device:     family.UserAgent,
+			IPAddress:  family.IPAddress,
+			LastSeenAt: family.LastSeenAt,
+			CreatedAt:  family.CreatedAt,
+			ExpiresAt:  family.ExpiresAt,
+			Current:    family.FamilyID == session.SessionID,
+		})
+	}
+
+	return res, nil
+}
+
+// endSessions revokes every active session of userID except keep and returns how many
+// it ended.
+func (us *UserService) endSessions(ctx context.Context, userID, keep uuid.UUID, reason string) (int, error) {
+	fields := logrus.Fields{
+		"user_id": userID,
+		"reason":  reason,
+	}
+
+	familyIDs, err := us.tokenRepo.RevokeUserTokenFamilies(ctx, nil, userID, keep, reason)
+	if err != nil {
+		utils.Log.WithError(err).WithFields(fields).Error("Failed to revoke token families")
+		return 0, constants.ErrRevokeSessions
+	}
+
+	for _, familyID := range familyIDs {
+		if err := us.jwtService.RevokeSession(ctx, familyID); err != nil {
+			utils.Log.WithError(err).WithFields(fields).WithField("session_id", familyID).Error("Failed to revoke session")
+			return 0, constants.ErrRevokeSessions
+		}
+	}
+
+	utils.Log.WithFields(fields).WithField("revoked", len(familyIDs)).Info("User sessions ended")
+
+	return len(familyIDs), nil
+}
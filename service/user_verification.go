@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/mailer"
+	"fieldreserve/model"
+	"fieldreserve/utils"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func emailVerificationTTL() time.Duration {
+	return time.Duration(helpers.GetEnvInt("EMAIL_VERIFICATION_TOKEN_TTL_HOURS", 24)) * time.Hour
+}
+
+// sendEmailVerification mails a fresh verification link; links sent earlier stop working.
+func (us *UserService) sendEmailVerification(ctx context.Context, user model.User) error {
+	token, tokenHash, err := helpers.GenerateUserToken()
+	if err != nil {
+		return err
+	}
+
+	ttl := emailVerificationTTL()
+	err = us.tokenRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := us.tokenRepo.InvalidateUserTokens(ctx, tx, user.UserID, constants.ENUM_USER_TOKEN_EMAIL_VERIFICATION); err != nil {
+			return err
+		}
+
+		return us.tokenRepo.CreateUserToken(ctx, tx, model.UserToken{
+			TokenID:   uuid.New(),
+			UserID:    user.UserID,
+			Purpose:   constants.ENUM_USER_TOKEN_EMAIL_VERIFICATION,
+			TokenHash: tokenHash,
+			ExpiresAt: time.Now().Add(ttl),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	link := userTokenLink("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email", token)
+	body := fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %d hours. You need a verified address to make bookings.",
+		user.Name, link, int(ttl.Hours()))
+	if err := us.mailer.Send(ctx, mailer.Message{To: user.Email, Subject: "Verify your email address", Body: body}); err != nil {
+		return err
+	}
+
+	utils.Log.WithField("user_id", user.UserID).Info("Verification email sent")
+
+	return nil
+}
+
+// VerifyEmail marks the address of the user a verification token was mailed to as verified.
+func (us *UserService) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error {
+	stored, found, err := us.tokenRepo.GetUserTokenByHash(ctx, nil, constants.ENUM_USER_TOKEN_EMAIL_VERIFICATION, helpers.HashUserToken(req.Token))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Log.WithError(err).Error("Failed to get email verification token")
+		return constants.ErrVerifyEmail
+	}
+	if !found {
+		return constants.ErrInvalidVerificationToken
+	}
+
+	err = us.tokenRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		consumed, err := us.tokenRepo.ConsumeUserToken(ctx, tx, stored.TokenID)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return constants.ErrInvalidVerificationToken
+		}
+
+		verifiedAt := time.Now()
+		return us.userRepo.SetEmailVerifiedAt(ctx, tx, stored.UserID.String(), &verifiedAt)
+	})
+	if errors.Is(err, constants.ErrInvalidVerificationToken) {
+		return err
+	}
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", stored.UserID).Error("Failed to verify email")
+		return constants.ErrVerifyEmail
+	}
+
+	utils.Log.WithField("user_id", stored.UserID).Info("Email verified")
+
+	return nil
+}
+
+// ResendEmailVerification mails the requesting user a new link. Sends are limited to one
+// per cooldown and a handful per hour so the endpoint can't be used to flood an inbox.
+func (us *UserService) ResendEmailVerification(ctx context.Context) error {
+	by, err := actorFromContext(ctx, us.jwtService)
+	if err != nil {
+		return err
+	}
+
+	user, _, err := us.userRepo.GetUserByID(ctx, nil, by.UserID.String())
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", by.UserID).Error("Failed to get user for verification resend")
+		return constants.ErrGetUserByID
+	}
+	if user.EmailVerifiedAt != nil {
+		return constants.ErrEmailAlreadyVerified
+	}
+
+	cooldown := time.Duration(helpers.GetEnvInt("EMAIL_VERIFICATION_RESEND_COOLDOWN_SECONDS", 60)) * time.Second
+	recent, err := us.tokenRepo.CountUserTokensSince(ctx, nil, user.UserID, constants.ENUM_USER_TOKEN_EMAIL_VERIFICATION, time.Now().Add(-cooldown))
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to count verification emails")
+		return constants.ErrSendVerification
+	}
+	if recent > 0 {
+		return constants.ErrVerificationRateLimited
+	}
+
+	lastHour, err := us.tokenRepo.CountUserTokensSince(ctx, nil, user.UserID, constants.ENUM_USER_TOKEN_EMAIL_VERIFICATION, time.Now().Add(-time.Hour))
+	if err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to count verification emails")
+		return constants.ErrSendVerification
+	}
+	if lastHour >= int64(helpers.GetEnvInt("EMAIL_VERIFICATION_MAX_PER_HOUR", 5)) {
+		utils.Log.WithField("user_id", user.UserID).Warn("Verification email hourly limit reached")
+		return constants.ErrVerificationRateLimited
+	}
+
+	if err := us.sendEmailVerification(ctx, user); err != nil {
+		utils.Log.WithError(err).WithField("user_id", user.UserID).Error("Failed to resend verification email")
+		return constants.ErrSendVerification
+	}
+
+	return nil
+}
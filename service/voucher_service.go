@@ -0,0 +1,500 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type (
+	IVoucherService interface {
+		CreateVoucher(ctx context.Context, req dto.CreateVoucherRequest) (dto.VoucherResponse, error)
+		GetVoucherByID(ctx context.Context, voucherID string) (dto.VoucherResponse, error)
+		GetAllVouchers(ctx context.Context) ([]dto.VoucherResponse, error)
+		UpdateVoucher(ctx context.Context, req dto.UpdateVoucherRequest) (dto.VoucherResponse, error)
+		DeleteVoucher(ctx context.Context, req dto.DeleteVoucherRequest) (dto.VoucherResponse, error)
+
+		// ResolvePromoCode checks that a promo code can be used by userID on field right now.
+		ResolvePromoCode(ctx context.Context, code string, userID uuid.UUID, field model.Field) (model.Voucher, error)
+		// RedeemVoucher records the use of voucher on booking inside the booking transaction.
+		RedeemVoucher(ctx context.Context, tx *gorm.DB, voucher model.Voucher, booking model.Booking, discount float64) error
+		// GetRedeemedVoucher returns the voucher used on booking, or nil when none was used.
+		GetRedeemedVoucher(ctx context.Context, bookingID uuid.UUID) (*model.Voucher, error)
+		UpdateRedemptionDiscount(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, discount float64) error
+		// RemoveRedemption drops the voucher from booking, giving the use back.
+		RemoveRedemption(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) error
+	}
+
+	VoucherService struct {
+		voucherRepo  repository.IVoucherRepository
+		fieldRepo    repository.IFieldRepository
+		categoryRepo repository.ICategoryRepository
+	}
+)
+
+func NewVoucherService(voucherRepo repository.IVoucherRepository, fieldRepo repository.IFieldRepository, categoryRepo repository.ICategoryRepository) *VoucherService {
+	return &VoucherService{
+		voucherRepo:  voucherRepo,
+		fieldRepo:    fieldRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (vs *VoucherService) CreateVoucher(ctx context.Context, req dto.CreateVoucherRequest) (dto.VoucherResponse, error) {
+	code := normalizePromoCode(req.Code)
+	utils.Log.Infof("Creating voucher: %s", code)
+
+	if _, found, _ := vs.voucherRepo.GetVoucherByCode(ctx, nil, code); found {
+		utils.Log.Warnf("Voucher code already exists: %s", code)
+		return dto.VoucherResponse{}, constants.ErrVoucherCodeExists
+	}
+
+	voucher := model.Voucher{
+		VoucherID:     uuid.New(),
+		Code:          code,
+		Description:   req.Description,
+		DiscountType:  req.DiscountType,
+		DiscountValue: req.DiscountValue,
+		MaxDiscount:   req.MaxDiscount,
+		MinSpend:      req.MinSpend,
+		UsageLimit:    req.UsageLimit,
+		PerUserLimit:  req.PerUserLimit,
+		IsActive:      true,
+	}
+	if req.IsActive != nil {
+		voucher.IsActive = *req.IsActive
+	}
+
+	var err error
+	if voucher.ValidFrom, err = parseOptionalDate(req.ValidFrom); err != nil {
+		return dto.VoucherResponse{}, err
+	}
+	if voucher.ValidUntil, err = parseOptionalDate(req.ValidUntil); err != nil {
+		return dto.VoucherResponse{}, err
+	}
+	if voucher.Fields, err = vs.loadFields(ctx, req.FieldIDs); err != nil {
+		return dto.VoucherResponse{}, err
+	}
+	if voucher.Categories, err = vs.loadCategories(ctx, req.CategoryIDs); err != nil {
+		return dto.VoucherResponse{}, err
+	}
+
+	if err := validateVoucher(voucher); err != nil {
+		return dto.VoucherResponse{}, err
+	}
+
+	if err := vs.voucherRepo.CreateVoucher(ctx, nil, voucher); err != nil {
+		utils.Log.Errorf("Failed to create voucher: %v", err)
+		return dto.VoucherResponse{}, constants.ErrCreateVoucher
+	}
+
+	utils.Log.Infof("Voucher created successfully: %s", voucher.VoucherID)
+
+	return toVoucherResponse(voucher, 0), nil
+}
+
+func (vs *VoucherService) GetVoucherByID(ctx context.Context, voucherID string) (dto.VoucherResponse, error) {
+	utils.Log.Infof("Fetching voucher by ID: %s", voucherID)
+
+	voucher, err := vs.getVoucher(ctx, voucherID)
+	if err != nil {
+		return dto.VoucherResponse{}, err
+	}
+
+	redeemed, err := vs.voucherRepo.CountVoucherRedemptions(ctx, nil, voucher.VoucherID, nil)
+	if err != nil {
+		utils.Log.Errorf("Failed to count voucher redemptions: %v", err)
+		return dto.VoucherResponse{}, constants.ErrGetVoucher
+	}
+
+	return toVoucherResponse(voucher, redeemed), nil
+}
+
+func (vs *VoucherService) GetAllVouchers(ctx context.Context) ([]dto.VoucherResponse, error) {
+	utils.Log.Info("Fetching all vouchers")
+
+	vouchers, err := vs.voucherRepo.GetAllVouchers(ctx, nil)
+	if err != nil {
+		utils.Log.Errorf("Failed to get vouchers: %v", err)
+		return nil, constants.ErrGetVoucher
+	}
+
+	res := []dto.VoucherResponse{}
+	for _, voucher := range vouchers {
+		redeemed, err := vs.voucherRepo.CountVoucherRedemptions(ctx, nil, voucher.VoucherID, nil)
+		if err != nil {
+			utils.Log.Errorf("Failed to count voucher redemptions: %v", err)
+			return nil, constants.ErrGetVoucher
+		}
+		res = append(res, toVoucherResponse(voucher, redeemed))
+	}
+
+	return res, nil
+}
+
+func (vs *VoucherService) UpdateVoucher(ctx context.Context, req dto.UpdateVoucherRequest) (dto.VoucherResponse, error) {
+	utils.Log.Infof("Updating voucher: %s", req.VoucherID)
+
+	voucher, err := vs.getVoucher(ctx, req.VoucherID)
+	if err != nil {
+		return dto.VoucherResponse{}, err
+	}
+
+	if req.Description != nil {
+		voucher.Description = *req.Description
+	}
+	if req.DiscountType != nil {
+		voucher.DiscountType = *req.DiscountType
+	}
+	if req.DiscountValue != nil {
+		voucher.DiscountValue = *req.DiscountValue
+	}
+	if req.MaxDiscount != nil {
+		voucher.MaxDiscount = *req.MaxDiscount
+	}
+	if req.MinSpend != nil {
+		voucher.MinSpend = *req.MinSpend
+	}
+	if req.ValidFrom != nil {
+		if voucher.ValidFrom, err = parseOptionalDate(*req.ValidFrom); err != nil {
+			return dto.VoucherResponse{}, err
+		}
+	}
+	if req.ValidUntil != nil {
+		if voucher.ValidUntil, err = parseOptionalDate(*req.ValidUntil); err != nil {
+			return dto.VoucherResponse{}, err
+		}
+	}
+	if req.UsageLimit != nil {
+		voucher.UsageLimit = *req.UsageLimit
+	}
+	if req.PerUserLimit != nil {
+		voucher.PerUserLimit = *req.PerUserLimit
+	}
+	if req.IsActive != nil {
+		voucher.IsActive = *req.IsActive
+	}
+	if req.FieldIDs != nil {
+		if voucher.Fields, err = vs.loadFields(ctx, *req.FieldIDs); err != nil {
+			return dto.VoucherResponse{}, err
+		}
+	}
+	if req.CategoryIDs != nil {
+		if voucher.Categories, err = vs.loadCategories(ctx, *req.CategoryIDs); err != nil {
+			return dto.VoucherResponse{}, err
+		}
+	}
+
+	if err := validateVoucher(voucher); err != nil {
+		return dto.VoucherResponse{}, err
+	}
+
+	if err := vs.voucherRepo.UpdateVoucher(ctx, nil, voucher); err != nil {
+		utils.Log.Errorf("Failed to update voucher: %v", err)
+		return dto.VoucherResponse{}, constants.ErrUpdateVoucher
+	}
+
+	utils.Log.Infof("Voucher updated successfully: %s", req.VoucherID)
+
+	redeemed, err := vs.voucherRepo.CountVoucherRedemptions(ctx, nil, voucher.VoucherID, nil)
+	if err != nil {
+		utils.Log.Errorf("Failed to count voucher redemptions: %v", err)
+		return dto.VoucherResponse{}, constants.ErrGetVoucher
+	}
+
+	return toVoucherResponse(voucher, redeemed), nil
+}
+
+// DeleteVoucher soft deletes the voucher. Existing redemptions keep their code and amount.
+func (vs *VoucherService) DeleteVoucher(ctx context.Context, req dto.DeleteVoucherRequest) (dto.VoucherResponse, error) {
+	utils.Log.Infof("Deleting voucher: %s", req.VoucherID)
+
+	voucher, err := vs.getVoucher(ctx, req.VoucherID)
+	if err != nil {
+		return dto.VoucherResponse{}, err
+	}
+
+	if err := vs.voucherRepo.DeleteVoucher(ctx, nil, req.VoucherID); err != nil {
+		utils.Log.Errorf("Failed to delete voucher: %v", err)
+		return dto.VoucherResponse{}, constants.ErrDeleteVoucher
+	}
+
+	utils.Log.Infof("Voucher deleted successfully: %s", req.VoucherID)
+
+	return toVoucherResponse(voucher, 0), nil
+}
+
+func (vs *VoucherService) ResolvePromoCode(ctx context.Context, code string, userID uuid.UUID, field model.Field) (model.Voucher, error) {
+	code = normalizePromoCode(code)
+	logFields := logrus.Fields{
+		"code":    code,
+		"userID":  userID,
+		"fieldID": field.FieldID,
+	}
+
+	voucher, _, err := vs.voucherRepo.GetVoucherByCode(ctx, nil, code)
+	if err != nil || !voucher.IsActive {
+		utils.Log.WithFields(logFields).Warn("Promo code not found or inactive")
+		return model.Voucher{}, constants.ErrPromoCodeInvalid
+	}
+
+	if !voucherValidOn(voucher, time.Now().In(helpers.GetAppLocation())) {
+		utils.Log.WithFields(logFields).Warn("Promo code used outside its validity period")
+		return model.Voucher{}, constants.ErrPromoCodeExpired
+	}
+
+	if !voucherAppliesToField(voucher, field) {
+		utils.Log.WithFields(logFields).Warn("Promo code does not apply to field")
+		return model.Voucher{}, constants.ErrPromoCodeNotApplicable
+	}
+
+	// Limits are checked again under lock when the voucher is redeemed; this early check
+	// only spares the customer a failed checkout.
+	if err := vs.checkVoucherLimits(ctx, nil, voucher, userID); err != nil {
+		return model.Voucher{}, err
+	}
+
+	return voucher, nil
+}
+
+func (vs *VoucherService) RedeemVoucher(ctx context.Context, tx *gorm.DB, voucher model.Voucher, booking model.Booking, discount float64) error {
+	if err := vs.voucherRepo.LockVoucher(ctx, tx, voucher.VoucherID); err != nil {
+		utils.Log.WithError(err).WithField("voucherID", voucher.VoucherID).Error("Failed to lock voucher")
+		return constants.ErrRedeemVoucher
+	}
+
+	if err := vs.checkVoucherLimits(ctx, tx, voucher, booking.UserID); err != nil {
+		return err
+	}
+
+	redemption := model.VoucherRedemption{
+		RedemptionID:   uuid.New(),
+		VoucherID:      voucher.VoucherID,
+		BookingID:      booking.BookingID,
+		UserID:         booking.UserID,
+		Code:           voucher.Code,
+		DiscountAmount: discount,
+		RedeemedAt:     time.Now().In(helpers.GetAppLocation()),
+	}
+	if err := vs.voucherRepo.CreateVoucherRedemption(ctx, tx, redemption); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to record voucher redemption")
+		return constants.ErrRedeemVoucher
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"voucherID": voucher.VoucherID,
+		"bookingID": booking.BookingID,
+		"discount":  discount,
+	}).Info("Voucher redeemed")
+
+	return nil
+}
+
+func (vs *VoucherService) GetRedeemedVoucher(ctx context.Context, bookingID uuid.UUID) (*model.Voucher, error) {
+	redemption, _, err := vs.voucherRepo.GetRedemptionByBookingID(ctx, nil, bookingID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to get voucher redemption")
+		return nil, constants.ErrGetVoucher
+	}
+
+	return &redemption.Voucher, nil
+}
+
+func (vs *VoucherService) UpdateRedemptionDiscount(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID, discount float64) error {
+	if err := vs.voucherRepo.UpdateRedemptionDiscount(ctx, tx, bookingID, discount); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to update voucher redemption")
+		return constants.ErrRedeemVoucher
+	}
+
+	return nil
+}
+
+func (vs *VoucherService) RemoveRedemption(ctx context.Context, tx *gorm.DB, bookingID uuid.UUID) error {
+	if err := vs.voucherRepo.DeleteRedemptionByBookingID(ctx, tx, bookingID); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", bookingID).Error("Failed to remove voucher redemption")
+		return constants.ErrRedeemVoucher
+	}
+
+	return nil
+}
+
+func (vs *VoucherService) checkVoucherLimits(ctx context.Context, tx *gorm.DB, voucher model.Voucher, userID uuid.UUID) error {
+	if voucher.UsageLimit > 0 {
+		used, err := vs.voucherRepo.CountVoucherRedemptions(ctx, tx, voucher.VoucherID, nil)
+		if err != nil {
+			utils.Log.WithError(err).WithField("voucherID", voucher.VoucherID).Error("Failed to count voucher redemptions")
+			return constants.ErrRedeemVoucher
+		}
+		if used >= int64(voucher.UsageLimit) {
+			utils.Log.WithField("voucherID", voucher.VoucherID).Warn("Voucher usage limit reached")
+			return constants.ErrPromoCodeUsedUp
+		}
+	}
+
+	if voucher.PerUserLimit > 0 {
+		used, err := vs.voucherRepo.CountVoucherRedemptions(ctx, tx, voucher.VoucherID, &userID)
+		if err != nil {
+			utils.Log.WithError(err).WithField("voucherID", voucher.VoucherID).Error("Failed to count voucher redemptions")
+			return constants.ErrRedeemVoucher
+		}
+		if used >= int64(voucher.PerUserLimit) {
+			utils.Log.WithFields(logrus.Fields{
+				"voucherID": voucher.VoucherID,
+				"userID":    userID,
+			}).Warn("Voucher per-user limit reached")
+			return constants.ErrPromoCodeUserLimit
+		}
+	}
+
+	return nil
+}
+
+func (vs *VoucherService) getVoucher(ctx context.Context, voucherID string) (model.Voucher, error) {
+	if _, err := uuid.Parse(voucherID); err != nil {
+		utils.Log.Errorf("Invalid voucher UUID: %v", err)
+		return model.Voucher{}, constants.ErrInvalidUUID
+	}
+
+	voucher, _, err := vs.voucherRepo.GetVoucherByID(ctx, nil, voucherID)
+	if err != nil {
+		utils.Log.Errorf("Voucher not found: %v", err)
+		return model.Voucher{}, constants.ErrVoucherNotFound
+	}
+
+	return voucher, nil
+}
+
+func (vs *VoucherService) loadFields(ctx context.Context, fieldIDs []string) ([]model.Field, error) {
+	fields := []model.Field{}
+	for _, fieldID := range fieldIDs {
+		if _, err := uuid.Parse(fieldID); err != nil {
+			utils.Log.Errorf("Invalid field UUID: %v", err)
+			return nil, constants.ErrInvalidUUID
+		}
+
+		field, _, err := vs.fieldRepo.GetFieldByID(ctx, nil, fieldID)
+		if err != nil {
+			utils.Log.Errorf("Field not found: %v", err)
+			return nil, constants.ErrFieldNotFound
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func (vs *VoucherService) loadCategories(ctx context.Context, categoryIDs []string) ([]model.Category, error) {
+	categories := []model.Category{}
+	for _, categoryID := range categoryIDs {
+		if _, err := uuid.Parse(categoryID); err != nil {
+			utils.Log.Errorf("Invalid category UUID: %v", err)
+			return nil, constants.ErrInvalidUUID
+		}
+
+		category, _, err := vs.categoryRepo.GetCategoryByID(ctx, nil, categoryID)
+		if err != nil {
+			utils.Log.Errorf("Category not found: %v", err)
+			return nil, constants.ErrGetCategoryByID
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+func validateVoucher(voucher model.Voucher) error {
+	if voucher.DiscountValue <= 0 {
+		utils.Log.Warn("Invalid voucher discount: <= 0")
+		return constants.ErrInvalidDiscount
+	}
+	if voucher.DiscountType == constants.ENUM_DISCOUNT_PERCENT && voucher.DiscountValue > 100 {
+		utils.Log.Warn("Invalid voucher discount: percentage above 100")
+		return constants.ErrInvalidDiscount
+	}
+
+	if voucher.ValidFrom != nil && voucher.ValidUntil != nil && voucher.ValidUntil.Before(*voucher.ValidFrom) {
+		utils.Log.Warn("Voucher valid until is before valid from")
+		return constants.ErrInvalidDateRange
+	}
+
+	return nil
+}
+
+// voucherAppliesToField reports whether field is one of the voucher's fields or belongs to
+// one of its categories. A voucher without either restriction applies everywhere.
+// voucherValidOn reports whether date falls inside the voucher's validity period.
+func voucherValidOn(voucher model.Voucher, date time.Time) bool {
+	day := date.Format("2006-01-02")
+	return (voucher.ValidFrom == nil || day >= voucher.ValidFrom.Format("2006-01-02")) &&
+		(voucher.ValidUntil == nil || day <= voucher.ValidUntil.Format("2006-01-02"))
+}
+
+func voucherAppliesToField(voucher model.Voucher, field model.Field) bool {
+	if len(voucher.Fields) == 0 && len(voucher.Categories) == 0 {
+		return true
+	}
+
+	for _, f := range voucher.Fields {
+		if f.FieldID == field.FieldID {
+			return true
+		}
+	}
+	for _, c := range voucher.Categories {
+		if c.CategoryID == field.CategoryID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func toVoucherResponse(voucher model.Voucher, redeemed int64) dto.VoucherResponse {
+	res := dto.VoucherResponse{
+		VoucherID:     voucher.VoucherID,
+		Code:          voucher.Code,
+		Description:   voucher.Description,
+		DiscountType:  voucher.DiscountType,
+		DiscountValue: voucher.DiscountValue,
+		MaxDiscount:   voucher.MaxDiscount,
+		MinSpend:      voucher.MinSpend,
+		UsageLimit:    voucher.UsageLimit,
+		PerUserLimit:  voucher.PerUserLimit,
+		IsActive:      voucher.IsActive,
+		FieldIDs:      []uuid.UUID{},
+		CategoryIDs:   []uuid.UUID{},
+		TimesRedeemed: redeemed,
+	}
+
+	if voucher.ValidFrom != nil {
+		res.ValidFrom = voucher.ValidFrom.Format("2006-01-02")
+	}
+	if voucher.ValidUntil != nil {
+		res.ValidUntil = voucher.ValidUntil.Format("2006-01-02")
+	}
+	for _, field := range voucher.Fields {
+		res.FieldIDs = append(res.FieldIDs, field.FieldID)
+	}
+	for _, category := range voucher.Categories {
+		res.CategoryIDs = append(res.CategoryIDs, category.CategoryID)
+	}
+
+	return res
+}
@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"fieldreserve/constants"
+	"fieldreserve/dto"
+	"fieldreserve/helpers"
+	"fieldreserve/model"
+	"fieldreserve/repository"
+	"fieldreserve/utils"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type (
+	IWaitlistService interface {
+		JoinWaitlist(ctx context.Context, req dto.JoinWaitlistRequest) (dto.WaitlistEntryResponse, error)
+		GetMyWaitlist(ctx context.Context) ([]dto.WaitlistEntryResponse, error)
+		LeaveWaitlist(ctx context.Context, waitlistID string) (dto.WaitlistEntryResponse, error)
+		GetWaitlistDemand(ctx context.Context, req dto.WaitlistDemandRequest) ([]dto.WaitlistDemandResponse, error)
+
+		// CheckSlotHold and FulfillWaitlist run inside the booking transaction, after the
+		// field lock is taken, so holds and bookings never race each other.
+		CheckSlotHold(ctx context.Context, tx *gorm.DB, booking model.Booking) error
+		FulfillWaitlist(ctx context.Context, tx *gorm.DB, booking model.Booking) error
+
+		// ReleaseSlot offers the slot of a booking that stopped holding it to the waitlist.
+		ReleaseSlot(ctx context.Context, booking model.Booking)
+		ExpireHolds(ctx context.Context)
+	}
+
+	WaitlistService struct {
+		waitlistRepo        repository.IWaitlistRepository
+		bookingRepo         repository.IBookingRepository
+		fieldRepo           repository.IFieldRepository
+		scheduleRepo        repository.IScheduleRepository
+		jwtService          InterfaceJWTService
+		notificationService INotificationService
+		holdDuration        time.Duration
+	}
+)
+
+func NewWaitlistService(
+	waitlistRepo repository.IWaitlistRepository,
+	bookingRepo repository.IBookingRepository,
+	fieldRepo repository.IFieldRepository,
+	scheduleRepo repository.IScheduleRepository,
+	jwtService InterfaceJWTService,
+	notificationService INotificationService,
+) *WaitlistService {
+	return &WaitlistService{
+		waitlistRepo:        waitlistRepo,
+		bookingRepo:         bookingRepo,
+		fieldRepo:           fieldRepo,
+		scheduleRepo:        scheduleRepo,
+		jwtService:          jwtService,
+		notificationService: notificationService,
+		holdDuration:        time.Duration(helpers.GetEnvInt("WAITLIST_HOLD_MINUTES", 30)) * time.Minute,
+	}
+}
+
+// JoinWaitlist queues the user for a slot that is currently taken. Free slots are
+// rejected so users book them directly instead of waiting for nothing.
+func (ws *WaitlistService) JoinWaitlist(ctx context.Context, req dto.JoinWaitlistRequest) (dto.WaitlistEntryResponse, error) {
+	utils.Log.WithFields(logrus.Fields{
+		"fieldID":     req.FieldID,
+		"bookingDate": req.BookingDate,
+		"startTime":   req.StartTime,
+		"endTime":     req.EndTime,
+	}).Info("Joining waitlist")
+
+	user, err := actorFromContext(ctx, ws.jwtService)
+	if err != nil {
+		return dto.WaitlistEntryResponse{}, err
+	}
+
+	fieldID, err := uuid.Parse(req.FieldID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", req.FieldID).Error("Failed to parse field ID")
+		return dto.WaitlistEntryResponse{}, constants.ErrInvalidUUID
+	}
+
+	bookingDate, startTime, endTime, err := parseBookingWindow(req.BookingDate, req.StartTime, req.EndTime, helpers.GetAppLocation())
+	if err != nil {
+		return dto.WaitlistEntryResponse{}, err
+	}
+
+	if _, err := validateBookingSlot(ctx, ws.fieldRepo, ws.scheduleRepo, req.FieldID, bookingDate, startTime, endTime); err != nil {
+		return dto.WaitlistEntryResponse{}, err
+	}
+
+	exists, err := ws.waitlistRepo.HasOpenWaitlistEntry(ctx, nil, user.UserID, fieldID, startTime, endTime)
+	if err != nil {
+		utils.Log.WithError(err).WithField("userID", user.UserID).Error("Failed to check existing waitlist entry")
+		return dto.WaitlistEntryResponse{}, constants.ErrJoinWaitlist
+	}
+	if exists {
+		return dto.WaitlistEntryResponse{}, constants.ErrAlreadyOnWaitlist
+	}
+
+	now := time.Now().In(helpers.GetAppLocation())
+	overlap, err := ws.bookingRepo.CheckBookingOverlap(ctx, nil, fieldID, bookingDate, startTime, endTime, uuid.Nil)
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", fieldID).Error("Failed to check booking overlap")
+		return dto.WaitlistEntryResponse{}, constants.ErrCheckOverlap
+	}
+	if !overlap {
+		held, err := ws.waitlistRepo.HasOverlappingHold(ctx, nil, fieldID, startTime, endTime, user.UserID, now)
+		if err != nil {
+			utils.Log.WithError(err).WithField("fieldID", fieldID).Error("Failed to check waitlist holds")
+			return dto.WaitlistEntryResponse{}, constants.ErrCheckOverlap
+		}
+		if !held {
+			utils.Log.WithField("fieldID", fieldID).Warn("Cannot join waitlist - slot is available")
+			return dto.WaitlistEntryResponse{}, constants.ErrSlotAvailable
+		}
+	}
+
+	entry := model.WaitlistEntry{
+		WaitlistID:  uuid.New(),
+		UserID:      user.UserID,
+		FieldID:     fieldID,
+		BookingDate: bookingDate,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Status:      constants.ENUM_WAITLIST_WAITING,
+	}
+	if err := ws.waitlistRepo.CreateWaitlistEntry(ctx, nil, entry); err != nil {
+		utils.Log.WithError(err).WithField("userID", user.UserID).Error("Failed to create waitlist entry")
+		return dto.WaitlistEntryResponse{}, constants.ErrJoinWaitlist
+	}
+
+	utils.Log.WithFields(logrus.Fields{
+		"waitlistID": entry.WaitlistID,
+		"userID":     user.UserID,
+		"fieldID":    fieldID,
+	}).Info("Joined waitlist successfully")
+
+	return toWaitlistEntryResponse(entry), nil
+}
+
+func (ws *WaitlistService) GetMyWaitlist(ctx context.Context) ([]dto.WaitlistEntryResponse, error) {
+	user, err := actorFromContext(ctx, ws.jwtService)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ws.waitlistRepo.GetWaitlistEntriesByUserID(ctx, nil, user.UserID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("userID", user.UserID).Error("Failed to get waitlist entries")
+		return nil, constants.ErrGetWaitlist
+	}
+
+	res := []dto.WaitlistEntryResponse{}
+	for _, entry := range entries {
+		res = append(res, toWaitlistEntryResponse(entry))
+	}
+
+	return res, nil
+}
+
+// LeaveWaitlist withdraws an open entry. Giving up an active hold passes the slot on
+// to the next entry straight away.
+func (ws *WaitlistService) LeaveWaitlist(ctx context.Context, waitlistID string) (dto.WaitlistEntryResponse, error) {
+	utils.Log.WithField("waitlistID", waitlistID).Info("Leaving waitlist")
+
+	user, err := actorFromContext(ctx, ws.jwtService)
+	if err != nil {
+		return dto.WaitlistEntryResponse{}, err
+	}
+
+	if _, err := uuid.Parse(waitlistID); err != nil {
+		utils.Log.WithError(err).WithField("waitlistID", waitlistID).Error("Invalid waitlist ID format")
+		return dto.WaitlistEntryResponse{}, constants.ErrInvalidUUID
+	}
+
+	entry, _, err := ws.waitlistRepo.GetWaitlistEntryByID(ctx, nil, waitlistID)
+	if err != nil {
+		utils.Log.WithError(err).WithField("waitlistID", waitlistID).Error("Waitlist entry not found")
+		return dto.WaitlistEntryResponse{}, constants.ErrWaitlistEntryNotFound
+	}
+
+	if err := authorize(user, entry.UserID, ownerOrAdmin, "leave waitlist entry", logrus.Fields{"waitlistID": waitlistID}); err != nil {
+		return dto.WaitlistEntryResponse{}, err
+	}
+
+	if entry.Status != constants.ENUM_WAITLIST_WAITING && entry.Status != constants.ENUM_WAITLIST_OFFERED {
+		return dto.WaitlistEntryResponse{}, constants.ErrWaitlistEntryClosed
+	}
+
+	wasOffered := entry.Status == constants.ENUM_WAITLIST_OFFERED
+	entry.Status = constants.ENUM_WAITLIST_CANCELLED
+	entry.HoldExpiresAt = nil
+	if err := ws.waitlistRepo.UpdateWaitlistEntry(ctx, nil, entry); err != nil {
+		utils.Log.WithError(err).WithField("waitlistID", waitlistID).Error("Failed to leave waitlist")
+		return dto.WaitlistEntryResponse{}, constants.ErrLeaveWaitlist
+	}
+
+	if wasOffered {
+		ws.offerSlot(ctx, entry.FieldID, entry.StartTime, entry.EndTime)
+	}
+
+	utils.Log.WithField("waitlistID", waitlistID).Info("Left waitlist successfully")
+
+	return toWaitlistEntryResponse(entry), nil
+}
+
+// GetWaitlistDemand summarises open waitlist entries for upcoming slots, per field.
+func (ws *WaitlistService) GetWaitlistDemand(ctx context.Context, req dto.WaitlistDemandRequest) ([]dto.WaitlistDemandResponse, error) {
+	if req.FieldID != "" {
+		if _, err := uuid.Parse(req.FieldID); err != nil {
+			utils.Log.WithError(err).WithField("fieldID", req.FieldID).Error("Invalid field ID format")
+			return nil, constants.ErrInvalidUUID
+		}
+	}
+
+	rows, err := ws.waitlistRepo.GetWaitlistDemand(ctx, nil, req.FieldID, time.Now().In(helpers.GetAppLocation()))
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", req.FieldID).Error("Failed to get waitlist demand")
+		return nil, constants.ErrGetWaitlist
+	}
+
+	res := []dto.WaitlistDemandResponse{}
+	index := make(map[uuid.UUID]int)
+	for _, row := range rows {
+		i, ok := index[row.FieldID]
+		if !ok {
+			i = len(res)
+			index[row.FieldID] = i
+			res = append(res, dto.WaitlistDemandResponse{
+				FieldID:   row.FieldID,
+				FieldName: row.FieldName,
+				Slots:     []dto.WaitlistSlotDemandResponse{},
+			})
+		}
+
+		res[i].WaitingCount += row.WaitingCount
+		res[i].OfferedCount += row.OfferedCount
+		res[i].Slots = append(res[i].Slots, dto.WaitlistSlotDemandResponse{
+			BookingDate:  row.BookingDate,
+			StartTime:    row.StartTime,
+			EndTime:      row.EndTime,
+			WaitingCount: row.WaitingCount,
+			OfferedCount: row.OfferedCount,
+		})
+	}
+
+	return res, nil
+}
+
+func (ws *WaitlistService) CheckSlotHold(ctx context.Context, tx *gorm.DB, booking model.Booking) error {
+	now := time.Now().In(helpers.GetAppLocation())
+	held, err := ws.waitlistRepo.HasOverlappingHold(ctx, tx, booking.FieldID, booking.StartTime, booking.EndTime, booking.UserID, now)
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", booking.FieldID).Error("Failed to check waitlist holds")
+		return constants.ErrCheckOverlap
+	}
+	if held {
+		utils.Log.WithFields(logrus.Fields{
+			"fieldID":   booking.FieldID,
+			"startTime": booking.StartTime,
+			"endTime":   booking.EndTime,
+		}).Warn("Booking time slot is held for the waitlist")
+		return constants.ErrSlotOnHold
+	}
+
+	return nil
+}
+
+func (ws *WaitlistService) FulfillWaitlist(ctx context.Context, tx *gorm.DB, booking model.Booking) error {
+	if err := ws.waitlistRepo.FulfillWaitlistEntries(ctx, tx, booking.UserID, booking.FieldID, booking.StartTime, booking.EndTime); err != nil {
+		utils.Log.WithError(err).WithField("bookingID", booking.BookingID).Error("Failed to fulfil waitlist entries")
+		return constants.ErrCreateBooking
+	}
+
+	return nil
+}
+
+func (ws *WaitlistService) ReleaseSlot(ctx context.Context, booking model.Booking) {
+	ws.offerSlot(ctx, booking.FieldID, booking.StartTime, booking.EndTime)
+}
+
+// ExpireHolds closes holds that were not turned into a booking in time and offers each
+// slot to the next entry in line.
+func (ws *WaitlistService) ExpireHolds(ctx context.Context) {
+	for ctx.Err() == nil {
+		var lapsed []model.WaitlistEntry
+
+		err := ws.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+			now := time.Now().In(helpers.GetAppLocation())
+
+			entries, err := ws.waitlistRepo.GetLapsedHolds(ctx, tx, now, expiryBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range entries {
+				entry.Status = constants.ENUM_WAITLIST_EXPIRED
+				if err := ws.waitlistRepo.UpdateWaitlistEntry(ctx, tx, entry); err != nil {
+					return err
+				}
+			}
+
+			lapsed = entries
+			return nil
+		})
+		if err != nil {
+			utils.Log.WithError(err).Error("Failed to expire waitlist holds")
+			return
+		}
+
+		for _, entry := range lapsed {
+			utils.Log.WithFields(logrus.Fields{
+				"waitlistID":    entry.WaitlistID,
+				"holdExpiresAt": entry.HoldExpiresAt,
+			}).Info("Waitlist hold expired")
+
+			ws.offerSlot(ctx, entry.FieldID, entry.StartTime, entry.EndTime)
+		}
+
+		if len(lapsed) < expiryBatchSize {
+			return
+		}
+	}
+}
+
+// offerSlot puts a hold on the oldest waiting entries overlapping [startTime, endTime)
+// whose own range is now completely free, then notifies their owners. Several entries
+// can be offered when they fit side by side in the released range.
+func (ws *WaitlistService) offerSlot(ctx context.Context, fieldID uuid.UUID, startTime, endTime time.Time) {
+	var offered []model.WaitlistEntry
+
+	err := ws.bookingRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := ws.bookingRepo.LockFieldForBooking(ctx, tx, fieldID); err != nil {
+			return err
+		}
+
+		now := time.Now().In(helpers.GetAppLocation())
+		entries, err := ws.waitlistRepo.GetWaitingEntriesForSlot(ctx, tx, fieldID, startTime, endTime, now.Add(bookingMinLeadTime))
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			overlap, err := ws.bookingRepo.CheckBookingOverlap(ctx, tx, fieldID, entry.BookingDate, entry.StartTime, entry.EndTime, uuid.Nil)
+			if err != nil {
+				return err
+			}
+			if overlap {
+				continue
+			}
+
+			held, err := ws.waitlistRepo.HasOverlappingHold(ctx, tx, fieldID, entry.StartTime, entry.EndTime, uuid.Nil, now)
+			if err != nil {
+				return err
+			}
+			if held {
+				continue
+			}
+
+			// The hold never runs past the point where the slot can no longer be booked.
+			holdExpiresAt := now.Add(ws.holdDuration)
+			if latest := entry.StartTime.Add(-bookingMinLeadTime); latest.Before(holdExpiresAt) {
+				holdExpiresAt = latest
+			}
+
+			entry.Status = constants.ENUM_WAITLIST_OFFERED
+			entry.OfferedAt = &now
+			entry.HoldExpiresAt = &holdExpiresAt
+			if err := ws.waitlistRepo.UpdateWaitlistEntry(ctx, tx, entry); err != nil {
+				return err
+			}
+
+			offered = append(offered, entry)
+		}
+
+		return nil
+	})
+	if err != nil {
+		utils.Log.WithError(err).WithField("fieldID", fieldID).Error("Failed to offer released slot to waitlist")
+		return
+	}
+
+	for _, entry := range offered {
+		utils.Log.WithFields(logrus.Fields{
+			"waitlistID":    entry.WaitlistID,
+			"userID":        entry.UserID,
+			"holdExpiresAt": entry.HoldExpiresAt,
+		}).Info("Released slot offered to waitlist")
+
+		message := fmt.Sprintf("The slot on %s from %s to %s is now available and held for you until %s.",
+			entry.BookingDate.Format("2006-01-02"),
+			entry.StartTime.Format("15:04"),
+			entry.EndTime.Format("15:04"),
+			entry.HoldExpiresAt.Format("2006-01-02 15:04"),
+		)
+		if err := ws.notificationService.Notify(ctx, entry.UserID, "Waitlist slot available", message); err != nil {
+			utils.Log.WithError(err).WithField("waitlistID", entry.WaitlistID).Error("Failed to notify waitlisted user")
+		}
+	}
+}
+
+func toWaitlistEntryResponse(entry model.WaitlistEntry) dto.WaitlistEntryResponse {
+	return dto.WaitlistEntryResponse{
+		WaitlistID:    entry.WaitlistID,
+		UserID:        entry.UserID,
+		FieldID:       entry.FieldID,
+		BookingDate:   entry.BookingDate,
+		StartTime:     entry.StartTime,
+		EndTime:       entry.EndTime,
+		Status:        entry.Status,
+		OfferedAt:     entry.OfferedAt,
+		HoldExpiresAt: entry.HoldExpiresAt,
+	}
+}
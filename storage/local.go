@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fieldreserve/helpers"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LocalSignedPathPrefix is the API route that serves signed links to local objects.
+const LocalSignedPathPrefix = "/api/files/"
+
+// LocalStorage keeps objects as files under a root directory. It suits a single instance
+// or replicas sharing a mounted volume.
+type LocalStorage struct {
+	root string
+}
+
+func NewLocalStorage(root string) *LocalStorage {
+	return &LocalStorage{
+		root: root,
+	}
+}
+
+// Path is where the object with key lives on disk.
+func (ls *LocalStorage) Path(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(ls.root, filepath.FromSlash(key)), nil
+}
+
+func (ls *LocalStorage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	target, err := ls.Path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a half-written object.
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), target)
+}
+
+func (ls *LocalStorage) Get(ctx context.Context, key string) (Object, error) {
+	target, err := ls.Path(key)
+	if err != nil {
+		return Object{}, err
+	}
+
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Object{}, ErrNotFound
+	}
+	if err != nil {
+		return Object{}, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return Object{}, err
+	}
+
+	return Object{
+		Body:        file,
+		ContentType: mime.TypeByExtension(filepath.Ext(target)),
+		Size:        info.Size(),
+	}, nil
+}
+
+func (ls *LocalStorage) Delete(ctx context.Context, key string) error {
+	target, err := ls.Path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL points at the API itself, which checks the signature before streaming the file.
+func (ls *LocalStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	return helpers.SignURL(LocalSignedPathPrefix+key, time.Now().Add(ttl), helpers.SignedURLSecret()), nil
+}
@@ -0,0 +1,111 @@
+// Package storage keeps uploaded files behind one interface, so every API replica reads
+// and writes the same files whether they live on local disk or in an S3-compatible bucket.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Folders group objects by what they hold. Keys are "<folder>/<file name>".
+const (
+	FolderFields = "fields"
+	FolderProofs = "proof"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+type (
+	Storage interface {
+		Put(ctx context.Context, key string, body io.Reader, contentType string) error
+		// Get returns the object; the caller must close its Body.
+		Get(ctx context.Context, key string) (Object, error)
+		Delete(ctx context.Context, key string) error
+		// SignedURL returns a link that opens the object without a session until ttl passes.
+		SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+	}
+
+	Object struct {
+		Body        io.ReadCloser
+		ContentType string
+		Size        int64
+	}
+)
+
+// NewFromEnv builds the backend named by STORAGE_DRIVER, defaulting to local disk.
+func NewFromEnv() (Storage, error) {
+	driver := os.Getenv("STORAGE_DRIVER")
+	if driver == "" {
+		driver = "local"
+	}
+
+	switch driver {
+	case "local":
+		root := os.Getenv("STORAGE_LOCAL_ROOT")
+		if root == "" {
+			root = "./storage"
+		}
+		return NewLocalStorage(root), nil
+	case "s3":
+		return NewS3StorageFromEnv()
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
+
+// Key joins a folder and a file name, rejecting names that would escape the folder.
+func Key(folder, name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", ErrInvalidKey
+	}
+	return path.Join(folder, name), nil
+}
+
+// PutUpload stores a multipart upload under folder with a unique name and returns that
+// name, which is what the database keeps.
+func PutUpload(ctx context.Context, store Storage, file *multipart.FileHeader, folder, prefix string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	name := fmt.Sprintf("%s_%d%s", prefix, time.Now().UnixNano(), ext)
+
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	contentType := file.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = mime.TypeByExtension(ext)
+	}
+
+	if err := store.Put(ctx, path.Join(folder, name), src, contentType); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// validKey guards backends against keys that are empty or climb out of the store.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"fieldreserve/constants"
 	"fieldreserve/dto"
 	"fmt"
 	"time"
@@ -10,13 +11,16 @@ import (
 	"github.com/skip2/go-qrcode"
 )
 
-func GenerateInvoicePDF(booking dto.BookingFullResponse) ([]byte, error) {
+// GenerateInvoicePDF renders the booking invoice. The booking QR encodes checkInToken,
+// which venue staff scan on arrival. paymentQR holds a QRIS payload for unpaid bookings
+// and is printed next to the booking QR; pass "" to leave it out.
+func GenerateInvoicePDF(booking dto.BookingFullResponse, checkInToken, paymentQR string) ([]byte, error) {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
 
 	pdf.SetMargins(20, 20, 20)
 
-	qrCode, err := qrcode.Encode(booking.BookingID.String(), qrcode.Medium, 256)
+	qrCode, err := qrcode.Encode(checkInToken, qrcode.Medium, 256)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate QR code: %v", err)
 	}
@@ -24,6 +28,14 @@ func GenerateInvoicePDF(booking dto.BookingFullResponse) ([]byte, error) {
 	qrReader := bytes.NewReader(qrCode)
 	pdf.RegisterImageOptionsReader("qr", gofpdf.ImageOptions{ImageType: "PNG"}, qrReader)
 
+	if paymentQR != "" {
+		qrisCode, err := qrcode.Encode(paymentQR, qrcode.Medium, 256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate payment QR code: %v", err)
+		}
+		pdf.RegisterImageOptionsReader("qris", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrisCode))
+	}
+
 	drawHeader(pdf)
 
 	pdf.SetY(35)
@@ -78,15 +90,36 @@ func GenerateInvoicePDF(booking dto.BookingFullResponse) ([]byte, error) {
 		drawInfoRow(pdf, "Diverifikasi pada:", booking.PaymentVerifiedAt.Format("02 Jan 2006 15:04 WIB"))
 	}
 
+	if booking.Voucher != nil {
+		pdf.SetFont("Arial", "", 11)
+		drawInfoRow(pdf, "Kode Promo:", fmt.Sprintf("%s (hemat Rp %s)", booking.Voucher.Code, formatCurrency(booking.Voucher.DiscountAmount)))
+	}
+
 	pdf.Ln(10)
 
+	if len(booking.PriceItems) > 0 {
+		drawSectionHeader(pdf, "RINCIAN BIAYA")
+		for _, item := range booking.PriceItems {
+			drawPriceItemRow(pdf, item)
+		}
+		pdf.Ln(5)
+	}
+
 	drawTotalPaymentSection(pdf, booking.TotalPayment)
 
 	pdf.Ln(10)
 
-	x := (210 - 60) / 2 
-	pdf.ImageOptions("qr", float64(x), pdf.GetY(), 60, 60, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
-	pdf.Ln(65)
+	if paymentQR != "" {
+		drawPaymentQRSection(pdf, booking)
+	} else {
+		x := (210 - 60) / 2 
+		pdf.ImageOptions("qr", float64(x), pdf.GetY(), 60, 60, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+		pdf.Ln(62)
+		pdf.SetFont("Arial", "", 9)
+		pdf.SetTextColor(52, 73, 94)
+		pdf.CellFormat(0, 5, "Tunjukkan kode ini saat check-in", "", 1, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
 
 
 	drawFooter(pdf)
@@ -130,6 +163,38 @@ func drawTotalPaymentSection(pdf *gofpdf.Fpdf, totalPayment float64) {
 	pdf.CellFormat(0, 15, fmt.Sprintf("TOTAL PEMBAYARAN: Rp %s", formatCurrency(totalPayment)), "", 1, "C", false, 0, "")
 }
 
+func drawPriceItemRow(pdf *gofpdf.Fpdf, item dto.PriceItemResponse) {
+	label := item.Label
+	if item.ItemType == constants.ENUM_PRICE_ITEM_BASE_RATE {
+		label = fmt.Sprintf("%s (%.1f jam x Rp %s)", item.Label, item.Quantity, formatCurrency(item.UnitPrice))
+	}
+
+	pdf.SetFont("Arial", "", 11)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(120, 8, label, "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 8, fmt.Sprintf("Rp %s", formatCurrency(item.Amount)), "", 1, "R", false, 0, "")
+}
+
+// drawPaymentQRSection places the booking QR and the QRIS payment QR side by side so an
+// unpaid invoice still fits on one page.
+func drawPaymentQRSection(pdf *gofpdf.Fpdf, booking dto.BookingFullResponse) {
+	y := pdf.GetY()
+	pdf.ImageOptions("qr", 35, y, 55, 55, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.ImageOptions("qris", 120, y, 55, 55, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	pdf.SetY(y + 57)
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(52, 73, 94)
+	pdf.SetX(20)
+	pdf.CellFormat(85, 5, "Kode check-in", "", 0, "C", false, 0, "")
+	pdf.CellFormat(85, 5, "Scan QRIS untuk membayar", "", 1, "C", false, 0, "")
+
+	if booking.PaymentDueAt != nil {
+		pdf.SetX(105)
+		pdf.CellFormat(85, 5, fmt.Sprintf("Bayar sebelum %s", booking.PaymentDueAt.Format("02 Jan 2006 15:04 WIB")), "", 1, "C", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+}
 
 func drawFooter(pdf *gofpdf.Fpdf) {
 	pdf.SetY(-30)